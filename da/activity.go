@@ -2,10 +2,9 @@ package da
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
 	"github.com/apprentice3d/forge-api-go-client/oauth"
-	"io/ioutil"
 	"net/http"
 	"strconv"
 )
@@ -33,22 +32,32 @@ type ActivityConfig struct {
 	Settings    Setting          `json:"settings"`
 }
 
+type ActivityList struct {
+	InfoList
+}
+
 type Activity struct {
 	ActivityConfig
 
 	authenticator oauth.ForgeAuthenticator
+	client        oauth.Doer
 	path          string
 	name          string
 
 }
 
 func (activity *Activity) Delete() (err error) {
-	bearer, err := activity.authenticator.GetToken("code:all")
+	return activity.DeleteContext(context.Background())
+}
+
+// DeleteContext is the same as Delete, but takes a context allowing to cancel the request or to set its deadline.
+func (activity *Activity) DeleteContext(ctx context.Context) (err error) {
+	bearer, err := oauth.GetTokenContext(ctx, activity.authenticator, "code:all")
 	if err != nil {
 		return
 	}
 
-	err = deleteActivity(activity.path, activity.ID, bearer.AccessToken)
+	err = deleteActivity(ctx, oauth.ClientOrDefault(activity.client), activity.path, activity.ID, bearer.AccessToken)
 
 	activity.Parameters = make(map[string]Param)
 	activity.ID = ""
@@ -58,6 +67,7 @@ func (activity *Activity) Delete() (err error) {
 	activity.Engine = ""
 	activity.Settings = Setting{}
 	activity.authenticator = nil
+	activity.client = nil
 	activity.path = ""
 	activity.name = ""
 
@@ -67,11 +77,16 @@ func (activity *Activity) Delete() (err error) {
 
 //CreateAlias creates a new alias for this Activity.
 func (activity Activity) CreateAlias(alias string, version uint) (result Alias, err error) {
-	bearer, err := activity.authenticator.GetToken("code:all")
+	return activity.CreateAliasContext(context.Background(), alias, version)
+}
+
+// CreateAliasContext is the same as CreateAlias, but takes a context allowing to cancel the request or to set its deadline.
+func (activity Activity) CreateAliasContext(ctx context.Context, alias string, version uint) (result Alias, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, activity.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	result, err = createActivityAlias(activity.path, activity.name, alias, version, bearer.AccessToken)
+	result, err = createActivityAlias(ctx, oauth.ClientOrDefault(activity.client), activity.path, activity.name, alias, version, bearer.AccessToken)
 
 	return
 }
@@ -86,9 +101,42 @@ func (activity Activity) CreateAlias(alias string, version uint) (result Alias,
   ACTIVITY
 */
 
-func createActivity(path string, activity ActivityConfig, token string) (result Activity, err error) {
+func listActivities(ctx context.Context, client oauth.Doer, path, page string, token string) (list ActivityList, err error) {
+
+	req, err := http.NewRequest("GET",
+		path+"/activities",
+		nil,
+	)
+
+	if err != nil {
+		return
+	}
+
+	if len(page) != 0 {
+		params := req.URL.Query()
+		params.Add("page", page)
+		req.URL.RawQuery = params.Encode()
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = oauth.NewAPIError(response)
+		return
+	}
 
-	task := http.Client{}
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&list)
+
+	return
+}
+
+func createActivity(ctx context.Context, client oauth.Doer, path string, activity ActivityConfig, token string) (result Activity, err error) {
 
 	body, err := json.Marshal(
 		activity)
@@ -106,15 +154,14 @@ func createActivity(path string, activity ActivityConfig, token string) (result
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -125,24 +172,22 @@ func createActivity(path string, activity ActivityConfig, token string) (result
 	return
 }
 
-func deleteActivity(path string, activityId string, token string) (err error) {
+func deleteActivity(ctx context.Context, client oauth.Doer, path string, activityId string, token string) (err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("DELETE",
 		path+"/activities/"+activityId,
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -155,24 +200,22 @@ func deleteActivity(path string, activityId string, token string) (err error) {
 	ALIASES
 */
 
-func listActivityAliases(path string, activityId, token string) (list AliasesList, err error) {
+func listActivityAliases(ctx context.Context, client oauth.Doer, path string, activityId, token string) (list AliasesList, err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("GET",
 		path+"/activities/"+activityId+"/aliases",
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -182,9 +225,7 @@ func listActivityAliases(path string, activityId, token string) (list AliasesLis
 	return
 }
 
-func createActivityAlias(path, activityId, alias string, version uint, token string) (result Alias, err error) {
-
-	task := http.Client{}
+func createActivityAlias(ctx context.Context, client oauth.Doer, path, activityId, alias string, version uint, token string) (result Alias, err error) {
 
 	body, err := json.Marshal(
 		Alias{
@@ -205,15 +246,14 @@ func createActivityAlias(path, activityId, alias string, version uint, token str
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -224,9 +264,7 @@ func createActivityAlias(path, activityId, alias string, version uint, token str
 	return
 }
 
-func modifyActivityAlias(path, activityId, alias string, version uint, token string) (result Alias, err error) {
-
-	task := http.Client{}
+func modifyActivityAlias(ctx context.Context, client oauth.Doer, path, activityId, alias string, version uint, token string) (result Alias, err error) {
 
 	body, err := json.Marshal(
 		struct {
@@ -246,15 +284,14 @@ func modifyActivityAlias(path, activityId, alias string, version uint, token str
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -265,9 +302,7 @@ func modifyActivityAlias(path, activityId, alias string, version uint, token str
 	return
 }
 
-func getActivityAliasDetails(path, activityId, alias, token string) (result Alias, err error) {
-
-	task := http.Client{}
+func getActivityAliasDetails(ctx context.Context, client oauth.Doer, path, activityId, alias, token string) (result Alias, err error) {
 
 	req, err := http.NewRequest("GET",
 		path+"/activities/"+activityId+"/aliases/"+alias,
@@ -279,15 +314,14 @@ func getActivityAliasDetails(path, activityId, alias, token string) (result Alia
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -300,24 +334,22 @@ func getActivityAliasDetails(path, activityId, alias, token string) (result Alia
 
 
 
-func deleteActivityAlias(path string, activityId, alias, token string) (err error) {
+func deleteActivityAlias(ctx context.Context, client oauth.Doer, path string, activityId, alias, token string) (err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("DELETE",
 		path+"/activities/"+activityId+"/aliases/"+alias,
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -329,24 +361,22 @@ func deleteActivityAlias(path string, activityId, alias, token string) (err erro
    VERSIONS
 */
 
-func listActivityVersions(path string, activityId, token string) (list VersionList, err error) {
+func listActivityVersions(ctx context.Context, client oauth.Doer, path string, activityId, token string) (list VersionList, err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("GET",
 		path+"/activities/"+activityId+"/versions",
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -356,9 +386,7 @@ func listActivityVersions(path string, activityId, token string) (list VersionLi
 	return
 }
 
-func createActivityVersion(path, activityId, engine string, token string) (result ActivityConfig, err error) {
-
-	task := http.Client{}
+func createActivityVersion(ctx context.Context, client oauth.Doer, path, activityId, engine string, token string) (result ActivityConfig, err error) {
 
 	body, err := json.Marshal(
 		struct{
@@ -378,15 +406,14 @@ func createActivityVersion(path, activityId, engine string, token string) (resul
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -399,9 +426,7 @@ func createActivityVersion(path, activityId, engine string, token string) (resul
 
 
 
-func getActivityVersionDetails(path, activityId string, version uint, token string) (result ActivityConfig, err error) {
-
-	task := http.Client{}
+func getActivityVersionDetails(ctx context.Context, client oauth.Doer, path, activityId string, version uint, token string) (result ActivityConfig, err error) {
 
 	req, err := http.NewRequest("GET",
 		path+"/activities/"+activityId+"/versions/"+strconv.Itoa(int(version)),
@@ -413,15 +438,14 @@ func getActivityVersionDetails(path, activityId string, version uint, token stri
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -434,24 +458,22 @@ func getActivityVersionDetails(path, activityId string, version uint, token stri
 
 
 
-func deleteActivityVersion(path, activityId string, version uint, token string) (err error) {
+func deleteActivityVersion(ctx context.Context, client oauth.Doer, path, activityId string, version uint, token string) (err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("DELETE",
 		path+"/activities/"+activityId+"/versions/"+strconv.Itoa(int(version)),
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
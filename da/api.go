@@ -1,12 +1,18 @@
 package da
 
-import "github.com/apprentice3d/forge-api-go-client/oauth"
+import (
+	"context"
+	"errors"
+
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+)
 
 // API struct holds all paths necessary to access Design Automation API
 type API struct {
 	Authenticator oauth.ForgeAuthenticator
 	DesignAutomationPath string
 	UploadAppURL string
+	Client oauth.Doer
 }
 
 // NewAPI returns a DesignAutomation API client with default configurations
@@ -15,18 +21,24 @@ func NewAPI(authenticator oauth.ForgeAuthenticator) API {
 		authenticator,
 		"/da/us-east/v3",
 		"https://dasprod-store.s3.amazonaws.com",
+		oauth.DefaultClient,
 	}
 }
 
 
 // UserId gives you the id used to identify the user
 func (api API) UserId() (nickname string, err error) {
-	bearer, err := api.Authenticator.GetToken("code:all")
+	return api.UserIdContext(context.Background())
+}
+
+// UserIdContext is the same as UserId, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) UserIdContext(ctx context.Context) (nickname string, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
-	nickname, err = getUserID(path, bearer.AccessToken)
+	nickname, err = getUserID(ctx, oauth.ClientOrDefault(api.Client), path, bearer.AccessToken)
 
 	return
 }
@@ -35,26 +47,36 @@ func (api API) UserId() (nickname string, err error) {
 
 // EngineList lists all available Engines.
 func (api API) EngineList() (list EngineList, err error) {
+	return api.EngineListContext(context.Background())
+}
+
+// EngineListContext is the same as EngineList, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) EngineListContext(ctx context.Context) (list EngineList, err error) {
 
-	bearer, err := api.Authenticator.GetToken("code:all")
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
-	list, err = listEngines(path, bearer.AccessToken)
+	list, err = listEngines(ctx, oauth.ClientOrDefault(api.Client), path, "", bearer.AccessToken)
 
 	return
 }
 
 // EngineDetails gives details on an engine providing it's id.
 func (api API) EngineDetails(id string) (list EngineDetails, err error) {
+	return api.EngineDetailsContext(context.Background(), id)
+}
+
+// EngineDetailsContext is the same as EngineDetails, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) EngineDetailsContext(ctx context.Context, id string) (list EngineDetails, err error) {
 
-	bearer, err := api.Authenticator.GetToken("code:all")
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
-	list, err = getEngineDetails(path, id, bearer.AccessToken)
+	list, err = getEngineDetails(ctx, oauth.ClientOrDefault(api.Client), path, id, bearer.AccessToken)
 
 	return
 }
@@ -64,15 +86,21 @@ func (api API) EngineDetails(id string) (list EngineDetails, err error) {
 // 	name - should be unique and will be the appID
 // 	engine - engineId to be used by this app (check EngineList)
 func (api API) CreateApp(name, engine string) (app AppBundle, err error) {
+	return api.CreateAppContext(context.Background(), name, engine)
+}
+
+// CreateAppContext is the same as CreateApp, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) CreateAppContext(ctx context.Context, name, engine string) (app AppBundle, err error) {
 
-	bearer, err := api.Authenticator.GetToken("code:all")
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
-	app, err = createApp(path, name, engine, bearer.AccessToken)
+	app, err = createApp(ctx, oauth.ClientOrDefault(api.Client), path, name, engine, bearer.AccessToken)
 
 	app.authenticator = api.Authenticator
+	app.client = api.Client
 	app.path = path
 	app.name = name
 	app.uploadURL = api.UploadAppURL
@@ -91,13 +119,36 @@ func (api API) CreateApp(name, engine string) (app AppBundle, err error) {
 
 // AppList lists all available appbundles.
 func (api API) AppList() (list AppList, err error) {
+	return api.AppListContext(context.Background())
+}
+
+// AppListContext is the same as AppList, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) AppListContext(ctx context.Context) (list AppList, err error) {
 
-	bearer, err := api.Authenticator.GetToken("code:all")
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
-	list, err = listApps(path, bearer.AccessToken)
+	list, err = listApps(ctx, oauth.ClientOrDefault(api.Client), path, "", bearer.AccessToken)
+
+	return
+}
+
+// ActivityList lists all available activities.
+func (api API) ActivityList() (list ActivityList, err error) {
+	return api.ActivityListContext(context.Background())
+}
+
+// ActivityListContext is the same as ActivityList, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) ActivityListContext(ctx context.Context) (list ActivityList, err error) {
+
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
+	list, err = listActivities(ctx, oauth.ClientOrDefault(api.Client), path, "", bearer.AccessToken)
 
 	return
 }
@@ -106,15 +157,21 @@ func (api API) AppList() (list AppList, err error) {
 // 	name - should be unique and will be the appID
 // 	engine - engineId to be used by this app (check EngineList)
 func (api API) CreateActivity(config ActivityConfig) (activity Activity, err error) {
+	return api.CreateActivityContext(context.Background(), config)
+}
 
-	bearer, err := api.Authenticator.GetToken("code:all")
+// CreateActivityContext is the same as CreateActivity, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) CreateActivityContext(ctx context.Context, config ActivityConfig) (activity Activity, err error) {
+
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
-	activity, err = createActivity(path, config, bearer.AccessToken)
+	activity, err = createActivity(ctx, oauth.ClientOrDefault(api.Client), path, config, bearer.AccessToken)
 
 	activity.authenticator = api.Authenticator
+	activity.client = api.Client
 	activity.path = path
 	activity.name = config.ID
 
@@ -127,6 +184,79 @@ func (api API) CreateActivity(config ActivityConfig) (activity Activity, err err
 	return
 }
 
+// CreateWorkItem submits a WorkItem, running the given activity with the specified arguments.
+// Use GetWorkItemStatus to follow its progress.
+func (api API) CreateWorkItem(config WorkItemConfig) (item WorkItem, err error) {
+	return api.CreateWorkItemContext(context.Background(), config)
+}
+
+// CreateWorkItemContext is the same as CreateWorkItem, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) CreateWorkItemContext(ctx context.Context, config WorkItemConfig) (item WorkItem, err error) {
+
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
+	item, err = createWorkItem(ctx, oauth.ClientOrDefault(api.Client), path, config, bearer.AccessToken)
+
+	return
+}
+
+// GetWorkItemStatus gets the status of a WorkItem, providing its id.
+func (api API) GetWorkItemStatus(id string) (item WorkItem, err error) {
+	return api.GetWorkItemStatusContext(context.Background(), id)
+}
+
+// GetWorkItemStatusContext is the same as GetWorkItemStatus, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) GetWorkItemStatusContext(ctx context.Context, id string) (item WorkItem, err error) {
+
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
+	item, err = getWorkItemStatus(ctx, oauth.ClientOrDefault(api.Client), path, id, bearer.AccessToken)
+
+	return
+}
+
+// CancelWorkItem cancels a pending or in progress WorkItem, providing its id.
+func (api API) CancelWorkItem(id string) (err error) {
+	return api.CancelWorkItemContext(context.Background(), id)
+}
+
+// CancelWorkItemContext is the same as CancelWorkItem, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) CancelWorkItemContext(ctx context.Context, id string) (err error) {
+
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
+	err = cancelWorkItem(ctx, oauth.ClientOrDefault(api.Client), path, id, bearer.AccessToken)
+
+	return
+}
+
+// GetWorkItemReport downloads the report log of a finished WorkItem.
+// 	Note: the report is available only after the WorkItem reached a final status, check WorkItem.IsFinished
+func (api API) GetWorkItemReport(item WorkItem) (report []byte, err error) {
+	return api.GetWorkItemReportContext(context.Background(), item)
+}
+
+// GetWorkItemReportContext is the same as GetWorkItemReport, but takes a context allowing to cancel the request or to set its deadline.
+func (api API) GetWorkItemReportContext(ctx context.Context, item WorkItem) (report []byte, err error) {
+
+	if len(item.ReportURL) == 0 {
+		err = errors.New("the report of workitem " + item.ID + " is not available, status: " + item.Status)
+		return
+	}
+	report, err = downloadReport(ctx, oauth.ClientOrDefault(api.Client), item.ReportURL)
+
+	return
+}
+
 
 
 
@@ -2,12 +2,11 @@ package da
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"encoding/xml"
-	"errors"
 	"fmt"
 	"github.com/apprentice3d/forge-api-go-client/oauth"
-	"io/ioutil"
 	"log"
 	"mime/multipart"
 	"net/http"
@@ -50,6 +49,7 @@ type AppBundle struct {
 	AppData
 
 	authenticator oauth.ForgeAuthenticator
+	client        oauth.Doer
 	path          string
 	name          string
 	uploadURL	string
@@ -81,13 +81,18 @@ type AppUploadError struct {
 
 // Delete removes the AppBundle, including all versions and aliases.
 func (app *AppBundle) Delete() (err error) {
+	return app.DeleteContext(context.Background())
+}
+
+// DeleteContext is the same as Delete, but takes a context allowing to cancel the request or to set its deadline.
+func (app *AppBundle) DeleteContext(ctx context.Context) (err error) {
 
-	bearer, err := app.authenticator.GetToken("code:all")
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
 
-	err = deleteApp(app.path, app.name, bearer.AccessToken)
+	err = deleteApp(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, bearer.AccessToken)
 
 	// TODO: research for a more elegant way of self-removing
 	app.Parameters = AppParameters{}
@@ -96,6 +101,7 @@ func (app *AppBundle) Delete() (err error) {
 	app.ID = ""
 	app.Version = 0
 	app.authenticator = nil
+	app.client = nil
 	app.path = ""
 	app.uploadURL = ""
 
@@ -104,22 +110,32 @@ func (app *AppBundle) Delete() (err error) {
 
 //Details gets the details of the specified AppBundle, providing an alias
 func (app *AppBundle) Details(alias string) (details AppDetails, err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.DetailsContext(context.Background(), alias)
+}
+
+// DetailsContext is the same as Details, but takes a context allowing to cancel the request or to set its deadline.
+func (app *AppBundle) DetailsContext(ctx context.Context, alias string) (details AppDetails, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	details, err = getAppDetails(app.path, app.ID + "+" + alias, bearer.AccessToken)
+	details, err = getAppDetails(ctx, oauth.ClientOrDefault(app.client), app.path, app.ID + "+" + alias, bearer.AccessToken)
 
 	return
 }
 
 //Aliases lists all aliases for the specified AppBundle.
 func (app AppBundle) Aliases() (list AliasesList, err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.AliasesContext(context.Background())
+}
+
+// AliasesContext is the same as Aliases, but takes a context allowing to cancel the request or to set its deadline.
+func (app AppBundle) AliasesContext(ctx context.Context) (list AliasesList, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	list, err = listAppAliases(app.path, app.name, bearer.AccessToken)
+	list, err = listAppAliases(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, bearer.AccessToken)
 
 	return
 }
@@ -127,66 +143,97 @@ func (app AppBundle) Aliases() (list AliasesList, err error) {
 //CreateAlias creates a new alias for this AppBundle.
 //	Limit: 1. Number of aliases (LimitAliases).
 func (app AppBundle) CreateAlias(alias string, version uint) (result Alias, err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.CreateAliasContext(context.Background(), alias, version)
+}
+
+// CreateAliasContext is the same as CreateAlias, but takes a context allowing to cancel the request or to set its deadline.
+func (app AppBundle) CreateAliasContext(ctx context.Context, alias string, version uint) (result Alias, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	result, err = createAppAlias(app.path, app.name, alias, version, bearer.AccessToken)
+	result, err = createAppAlias(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, alias, version, bearer.AccessToken)
 
 	return
 }
 
 //ModifyAlias will switch the given alias to another existing version
 func (app AppBundle) ModifyAlias(alias string, version uint) (result Alias, err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.ModifyAliasContext(context.Background(), alias, version)
+}
+
+// ModifyAliasContext is the same as ModifyAlias, but takes a context allowing to cancel the request or to set its deadline.
+func (app AppBundle) ModifyAliasContext(ctx context.Context, alias string, version uint) (result Alias, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	result, err = modifyAppAlias(app.path, app.name, alias, version, bearer.AccessToken)
+	result, err = modifyAppAlias(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, alias, version, bearer.AccessToken)
 
 	return
 }
 
 //AliasDetail gets the details on given alias
 func (app *AppBundle) AliasDetail(alias string) (details Alias, err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.AliasDetailContext(context.Background(), alias)
+}
+
+// AliasDetailContext is the same as AliasDetail, but takes a context allowing to cancel the request or to set its deadline.
+func (app *AppBundle) AliasDetailContext(ctx context.Context, alias string) (details Alias, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	details, err = getAliasDetails(app.path, app.name, alias, bearer.AccessToken)
+	details, err = getAliasDetails(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, alias, bearer.AccessToken)
 
 	return
 }
 
 //DeleteAlias the alias for this AppBundle.
 func (app AppBundle) DeleteAlias(alias string) (err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.DeleteAliasContext(context.Background(), alias)
+}
+
+// DeleteAliasContext is the same as DeleteAlias, but takes a context allowing to cancel the request or to set its deadline.
+func (app AppBundle) DeleteAliasContext(ctx context.Context, alias string) (err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	err = deleteAppAlias(app.path, app.name, alias, bearer.AccessToken)
+	err = deleteAppAlias(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, alias, bearer.AccessToken)
 
 	return
 }
 
 //Versions lists all aliases for the specified AppBundle.
 func (app AppBundle) Versions() (list VersionList, err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.VersionsContext(context.Background())
+}
+
+// VersionsContext is the same as Versions, but takes a context allowing to cancel the request or to set its deadline.
+func (app AppBundle) VersionsContext(ctx context.Context) (list VersionList, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	list, err = listAppVersions(app.path, app.name, bearer.AccessToken)
+	list, err = listAppVersions(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, bearer.AccessToken)
 
 	return
 }
 
 func (app AppBundle) CreateVersion(engine string) (result AppBundle, err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.CreateVersionContext(context.Background(), engine)
+}
+
+// CreateVersionContext is the same as CreateVersion, but takes a context allowing to cancel the request or to set its deadline.
+func (app AppBundle) CreateVersionContext(ctx context.Context, engine string) (result AppBundle, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	result, err = createAppVersion(app.path, app.name, engine, bearer.AccessToken)
+	result, err = createAppVersion(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, engine, bearer.AccessToken)
 	result.authenticator = app.authenticator
+	result.client = app.client
 	result.name = app.name
 	result.path = app.path
 
@@ -195,31 +242,46 @@ func (app AppBundle) CreateVersion(engine string) (result AppBundle, err error)
 
 
 func (app *AppBundle) VersionDetails(version uint) (details AppData, err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.VersionDetailsContext(context.Background(), version)
+}
+
+// VersionDetailsContext is the same as VersionDetails, but takes a context allowing to cancel the request or to set its deadline.
+func (app *AppBundle) VersionDetailsContext(ctx context.Context, version uint) (details AppData, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	details, err = getVersionDetails(app.path, app.name, version, bearer.AccessToken)
+	details, err = getVersionDetails(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, version, bearer.AccessToken)
 
 	return
 }
 
 
 func (app AppBundle) DeleteVersion(version uint) (err error) {
-	bearer, err := app.authenticator.GetToken("code:all")
+	return app.DeleteVersionContext(context.Background(), version)
+}
+
+// DeleteVersionContext is the same as DeleteVersion, but takes a context allowing to cancel the request or to set its deadline.
+func (app AppBundle) DeleteVersionContext(ctx context.Context, version uint) (err error) {
+	bearer, err := oauth.GetTokenContext(ctx, app.authenticator, "code:all")
 	if err != nil {
 		return
 	}
-	err = deleteAppVersion(app.path, app.name, version, bearer.AccessToken)
+	err = deleteAppVersion(ctx, oauth.ClientOrDefault(app.client), app.path, app.name, version, bearer.AccessToken)
 
 	return
 }
 
 
 
-func (app AppBundle) Upload(data []byte) (err error){
+func (app AppBundle) Upload(data []byte) (err error) {
+	return app.UploadContext(context.Background(), data)
+}
+
+// UploadContext is the same as Upload, but takes a context allowing to cancel the request or to set its deadline.
+func (app AppBundle) UploadContext(ctx context.Context, data []byte) (err error) {
 
-	err = uploadApp(app.uploadURL, app.Parameters.Data, data)
+	err = uploadApp(ctx, oauth.ClientOrDefault(app.client), app.uploadURL, app.Parameters.Data, data)
 
 	return
 }
@@ -236,24 +298,32 @@ func (app AppBundle) Upload(data []byte) (err error){
    APPBUNDLE
 */
 
-func listApps(path string, token string) (list AppList, err error) {
+func listApps(ctx context.Context, client oauth.Doer, path, page string, token string) (list AppList, err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("GET",
 		path+"/appbundles",
 		nil,
 	)
 
+	if err != nil {
+		return
+	}
+
+	if len(page) != 0 {
+		params := req.URL.Query()
+		params.Add("page", page)
+		req.URL.RawQuery = params.Encode()
+	}
+
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -263,9 +333,7 @@ func listApps(path string, token string) (list AppList, err error) {
 	return
 }
 
-func createApp(path, name, engine, token string) (result AppBundle, err error) {
-
-	task := http.Client{}
+func createApp(ctx context.Context, client oauth.Doer, path, name, engine, token string) (result AppBundle, err error) {
 
 	body, err := json.Marshal(
 		CreateAppRequest{
@@ -286,15 +354,14 @@ func createApp(path, name, engine, token string) (result AppBundle, err error) {
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -305,9 +372,7 @@ func createApp(path, name, engine, token string) (result AppBundle, err error) {
 	return
 }
 
-func getAppDetails(path, appID, token string) (result AppDetails, err error) {
-
-	task := http.Client{}
+func getAppDetails(ctx context.Context, client oauth.Doer, path, appID, token string) (result AppDetails, err error) {
 
 	req, err := http.NewRequest("GET",
 		path+"/appbundles/"+appID,
@@ -319,15 +384,14 @@ func getAppDetails(path, appID, token string) (result AppDetails, err error) {
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -338,24 +402,22 @@ func getAppDetails(path, appID, token string) (result AppDetails, err error) {
 	return
 }
 
-func deleteApp(path string, id string, token string) (err error) {
+func deleteApp(ctx context.Context, client oauth.Doer, path string, id string, token string) (err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("DELETE",
 		path+"/appbundles/"+id,
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -367,24 +429,22 @@ func deleteApp(path string, id string, token string) (err error) {
 	ALIASES
 */
 
-func listAppAliases(path string, appName, token string) (list AliasesList, err error) {
+func listAppAliases(ctx context.Context, client oauth.Doer, path string, appName, token string) (list AliasesList, err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("GET",
 		path+"/appbundles/"+appName+"/aliases",
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -394,9 +454,7 @@ func listAppAliases(path string, appName, token string) (list AliasesList, err e
 	return
 }
 
-func createAppAlias(path, appName, alias string, version uint, token string) (result Alias, err error) {
-
-	task := http.Client{}
+func createAppAlias(ctx context.Context, client oauth.Doer, path, appName, alias string, version uint, token string) (result Alias, err error) {
 
 	body, err := json.Marshal(
 		Alias{
@@ -417,15 +475,14 @@ func createAppAlias(path, appName, alias string, version uint, token string) (re
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -436,9 +493,7 @@ func createAppAlias(path, appName, alias string, version uint, token string) (re
 	return
 }
 
-func modifyAppAlias(path, appName, alias string, version uint, token string) (result Alias, err error) {
-
-	task := http.Client{}
+func modifyAppAlias(ctx context.Context, client oauth.Doer, path, appName, alias string, version uint, token string) (result Alias, err error) {
 
 	body, err := json.Marshal(
 		struct {
@@ -458,15 +513,14 @@ func modifyAppAlias(path, appName, alias string, version uint, token string) (re
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -477,9 +531,7 @@ func modifyAppAlias(path, appName, alias string, version uint, token string) (re
 	return
 }
 
-func getAliasDetails(path, appName, alias, token string) (result Alias, err error) {
-
-	task := http.Client{}
+func getAliasDetails(ctx context.Context, client oauth.Doer, path, appName, alias, token string) (result Alias, err error) {
 
 	req, err := http.NewRequest("GET",
 		path+"/appbundles/"+appName+"/aliases/"+alias,
@@ -491,15 +543,14 @@ func getAliasDetails(path, appName, alias, token string) (result Alias, err erro
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -512,24 +563,22 @@ func getAliasDetails(path, appName, alias, token string) (result Alias, err erro
 
 
 
-func deleteAppAlias(path string, appName, alias, token string) (err error) {
+func deleteAppAlias(ctx context.Context, client oauth.Doer, path string, appName, alias, token string) (err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("DELETE",
 		path+"/appbundles/"+appName+"/aliases/"+alias,
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -541,24 +590,22 @@ func deleteAppAlias(path string, appName, alias, token string) (err error) {
    VERSIONS
 */
 
-func listAppVersions(path string, appName, token string) (list VersionList, err error) {
+func listAppVersions(ctx context.Context, client oauth.Doer, path string, appName, token string) (list VersionList, err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("GET",
 		path+"/appbundles/"+appName+"/versions",
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -568,9 +615,7 @@ func listAppVersions(path string, appName, token string) (list VersionList, err
 	return
 }
 
-func createAppVersion(path, appName, engine string, token string) (result AppBundle, err error) {
-
-	task := http.Client{}
+func createAppVersion(ctx context.Context, client oauth.Doer, path, appName, engine string, token string) (result AppBundle, err error) {
 
 	body, err := json.Marshal(
 		struct{
@@ -590,15 +635,14 @@ func createAppVersion(path, appName, engine string, token string) (result AppBun
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -611,9 +655,7 @@ func createAppVersion(path, appName, engine string, token string) (result AppBun
 
 
 
-func getVersionDetails(path, appName string, version uint, token string) (result AppData, err error) {
-
-	task := http.Client{}
+func getVersionDetails(ctx context.Context, client oauth.Doer, path, appName string, version uint, token string) (result AppData, err error) {
 
 	req, err := http.NewRequest("GET",
 		path+"/appbundles/"+appName+"/versions/"+strconv.Itoa(int(version)),
@@ -625,15 +667,14 @@ func getVersionDetails(path, appName string, version uint, token string) (result
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -646,24 +687,22 @@ func getVersionDetails(path, appName string, version uint, token string) (result
 
 
 
-func deleteAppVersion(path string, appName string, version uint, token string) (err error) {
+func deleteAppVersion(ctx context.Context, client oauth.Doer, path string, appName string, version uint, token string) (err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("DELETE",
 		path+"/appbundles/"+appName+"/versions/"+strconv.Itoa(int(version)),
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusNoContent {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -671,7 +710,7 @@ func deleteAppVersion(path string, appName string, version uint, token string) (
 }
 
 
-func uploadApp(path string, formData FormData, data []byte) (err error) {
+func uploadApp(ctx context.Context, client oauth.Doer, path string, formData FormData, data []byte) (err error) {
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
@@ -695,8 +734,6 @@ func uploadApp(path string, formData FormData, data []byte) (err error) {
 	formFile.Write(data)
 	writer.Close()
 
-	task := http.Client{}
-
 	req, err := http.NewRequest("POST",
 		path,
 		body)
@@ -706,7 +743,7 @@ func uploadApp(path string, formData FormData, data []byte) (err error) {
 	}
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return
@@ -714,21 +751,23 @@ func uploadApp(path string, formData FormData, data []byte) (err error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		decoder := xml.NewDecoder(response.Body)
-		errorDetails := AppUploadError{}
-		err = decoder.Decode(&errorDetails)
+		apiErr := oauth.NewAPIError(response)
 
-		if err != nil {
-			return
+		// S3 is reporting the errors as XML
+		errorDetails := AppUploadError{}
+		if xml.Unmarshal(apiErr.Body, &errorDetails) == nil {
+			apiErr.Code = errorDetails.Code
+			apiErr.Reason = errorDetails.Message
+			apiErr.Details = errorDetails
+			apiErr.Message = fmt.Sprintf("[%d][%s] - %s {%s}",
+				response.StatusCode,
+				errorDetails.Code,
+				errorDetails.Message,
+				errorDetails.Condition,
+			)
 		}
 
-
-		err = errors.New(fmt.Sprintf("[%d][%s] - %s {%s}",
-			response.StatusCode,
-			errorDetails.Code,
-			errorDetails.Message,
-			errorDetails.Condition,
-		))
+		err = apiErr
 		return
 	}
 
@@ -1,11 +1,10 @@
 package da
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
 	"net/http"
-	"strconv"
 )
 
 type EngineList struct {
@@ -21,24 +20,32 @@ type EngineDetails struct {
 
 
 
-func listEngines(path string, token string) (list EngineList, err error) {
+func listEngines(ctx context.Context, client oauth.Doer, path, page string, token string) (list EngineList, err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("GET",
 		path+"/engines",
 		nil,
 	)
 
+	if err != nil {
+		return
+	}
+
+	if len(page) != 0 {
+		params := req.URL.Query()
+		params.Add("page", page)
+		req.URL.RawQuery = params.Encode()
+	}
+
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -49,24 +56,22 @@ func listEngines(path string, token string) (list EngineList, err error) {
 }
 
 
-func getEngineDetails(path string, engineID string, token string) (details EngineDetails, err error) {
+func getEngineDetails(ctx context.Context, client oauth.Doer, path string, engineID string, token string) (details EngineDetails, err error) {
 
-	task := http.Client{}
 	req, err := http.NewRequest("GET",
 		path+"/engines/"+engineID,
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
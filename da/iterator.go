@@ -0,0 +1,137 @@
+package da
+
+import (
+	"context"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+)
+
+// AppIterator walks through the ids of all appbundles, requesting the next page when needed.
+// 	Use it as:
+// 	it := api.Apps()
+// 	for it.Next() {
+// 		id := it.App()
+// 	}
+// 	if it.Err() != nil {...}
+// 	The iteration can be stopped at any time, no more pages being requested.
+type AppIterator struct {
+	idIterator
+}
+
+// EngineIterator walks through the ids of all engines, the same way as AppIterator.
+type EngineIterator struct {
+	idIterator
+}
+
+// ActivityIterator walks through the ids of all activities, the same way as AppIterator.
+type ActivityIterator struct {
+	idIterator
+}
+
+// idIterator follows the paginationToken of the listings, shared by the iterators
+type idIterator struct {
+	ctx   context.Context
+	fetch func(ctx context.Context, page string) (InfoList, error)
+
+	page    string
+	started bool
+	err     error
+	items   []string
+	current string
+}
+
+// Apps returns an iterator over the ids of all appbundles.
+func (api API) Apps() *AppIterator {
+	return api.AppsContext(context.Background())
+}
+
+// AppsContext is the same as Apps, but takes a context allowing to cancel the requests or to set their deadline.
+func (api API) AppsContext(ctx context.Context) *AppIterator {
+	return &AppIterator{api.newIDIterator(ctx, func(ctx context.Context, client oauth.Doer, path, page, token string) (InfoList, error) {
+		list, err := listApps(ctx, client, path, page, token)
+		return list.InfoList, err
+	})}
+}
+
+// App returns the id of current appbundle, valid after Next returned true
+func (it *AppIterator) App() string {
+	return it.current
+}
+
+// Engines returns an iterator over the ids of all engines.
+func (api API) Engines() *EngineIterator {
+	return api.EnginesContext(context.Background())
+}
+
+// EnginesContext is the same as Engines, but takes a context allowing to cancel the requests or to set their deadline.
+func (api API) EnginesContext(ctx context.Context) *EngineIterator {
+	return &EngineIterator{api.newIDIterator(ctx, func(ctx context.Context, client oauth.Doer, path, page, token string) (InfoList, error) {
+		list, err := listEngines(ctx, client, path, page, token)
+		return list.InfoList, err
+	})}
+}
+
+// Engine returns the id of current engine, valid after Next returned true
+func (it *EngineIterator) Engine() string {
+	return it.current
+}
+
+// Activities returns an iterator over the ids of all activities.
+func (api API) Activities() *ActivityIterator {
+	return api.ActivitiesContext(context.Background())
+}
+
+// ActivitiesContext is the same as Activities, but takes a context allowing to cancel the requests or to set their deadline.
+func (api API) ActivitiesContext(ctx context.Context) *ActivityIterator {
+	return &ActivityIterator{api.newIDIterator(ctx, func(ctx context.Context, client oauth.Doer, path, page, token string) (InfoList, error) {
+		list, err := listActivities(ctx, client, path, page, token)
+		return list.InfoList, err
+	})}
+}
+
+// Activity returns the id of current activity, valid after Next returned true
+func (it *ActivityIterator) Activity() string {
+	return it.current
+}
+
+// Next advances to the next id, returning false when there are no more ids or an error occurred.
+func (it *idIterator) Next() bool {
+	for len(it.items) == 0 {
+		if it.err != nil || (it.started && len(it.page) == 0) {
+			return false
+		}
+		it.started = true
+
+		list, err := it.fetch(it.ctx, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.page = list.Pagination
+		it.items = list.Data
+	}
+
+	it.current, it.items = it.items[0], it.items[1:]
+
+	return true
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *idIterator) Err() error {
+	return it.err
+}
+
+// newIDIterator binds the listing to the authentication and client of the API, requesting a token for each page
+func (api API) newIDIterator(ctx context.Context, list func(ctx context.Context, client oauth.Doer, path, page, token string) (InfoList, error)) idIterator {
+	return idIterator{
+		ctx: ctx,
+		fetch: func(ctx context.Context, page string) (result InfoList, err error) {
+			bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "code:all")
+			if err != nil {
+				return
+			}
+			path := api.Authenticator.GetHostPath() + api.DesignAutomationPath
+
+			return list(ctx, oauth.ClientOrDefault(api.Client), path, page, bearer.AccessToken)
+		},
+	}
+}
@@ -0,0 +1,62 @@
+package da_test
+
+import (
+	"github.com/apprentice3d/forge-api-go-client/da"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPI_Iterators(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		switch {
+		case r.URL.Path == "/authentication/v1/authenticate":
+			w.Write([]byte(`{"token_type":"Bearer","expires_in":3599,"access_token":"fake_token"}`))
+		case r.URL.Path == "/da/us-east/v3/appbundles" && page == "":
+			w.Write([]byte(`{"paginationToken":"next_apps","data":["nickname.App1+default"]}`))
+		case r.URL.Path == "/da/us-east/v3/appbundles" && page == "next_apps":
+			w.Write([]byte(`{"data":["nickname.App2+default","nickname.App3+default"]}`))
+		case r.URL.Path == "/da/us-east/v3/engines":
+			w.Write([]byte(`{"data":["Autodesk.AutoCAD+23"]}`))
+		case r.URL.Path == "/da/us-east/v3/activities":
+			w.Write([]byte(`{"data":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	authenticator := oauth.NewTwoLegged("id", "secret")
+	authenticator.Host = server.URL
+	authenticator.Client = server.Client()
+
+	daApi := da.NewAPI(authenticator)
+	daApi.Client = server.Client()
+
+	t.Run("Iterate over app pages", func(t *testing.T) {
+		var apps []string
+		it := daApi.Apps()
+		for it.Next() {
+			apps = append(apps, it.App())
+		}
+
+		if it.Err() != nil || len(apps) != 3 || apps[2] != "nickname.App3+default" {
+			t.Errorf("Expected apps from both pages, got %v with error %v", apps, it.Err())
+		}
+	})
+
+	t.Run("Iterate over engines and activities", func(t *testing.T) {
+		engines := daApi.Engines()
+		if !engines.Next() || engines.Engine() != "Autodesk.AutoCAD+23" || engines.Next() {
+			t.Errorf("Expected a single engine, got %s with error %v", engines.Engine(), engines.Err())
+		}
+
+		activities := daApi.Activities()
+		if activities.Next() || activities.Err() != nil {
+			t.Errorf("Expected no activities, got %s with error %v", activities.Activity(), activities.Err())
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package da_test
+
+import (
+	"encoding/json"
+	"github.com/apprentice3d/forge-api-go-client/da"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPI_WorkItem(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/authentication/v1/authenticate":
+			w.Write([]byte(`{"token_type":"Bearer","expires_in":3599,"access_token":"fake_token"}`))
+		case r.URL.Path == "/da/us-east/v3/workitems" && r.Method == http.MethodPost:
+			var config da.WorkItemConfig
+			if err := json.NewDecoder(r.Body).Decode(&config); err != nil || config.ActivityID != "nickname.Activity+default" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"id":"item_id","status":"pending"}`))
+		case r.URL.Path == "/da/us-east/v3/workitems/item_id" && r.Method == http.MethodGet:
+			w.Write([]byte(`{"id":"item_id","status":"success","reportUrl":"http://` + r.Host + `/report.txt"}`))
+		case r.URL.Path == "/da/us-east/v3/workitems/item_id" && r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/report.txt":
+			// the report is stored on a pre-signed URL
+			if len(r.Header.Get("Authorization")) != 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte("report log"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"diagnostic":"The WorkItem was not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	authenticator := oauth.NewTwoLegged("id", "secret")
+	authenticator.Host = server.URL
+	authenticator.Client = server.Client()
+
+	daApi := da.NewAPI(authenticator)
+	daApi.Client = server.Client()
+
+	t.Run("Create workitem", func(t *testing.T) {
+		item, err := daApi.CreateWorkItem(da.WorkItemConfig{ActivityID: "nickname.Activity+default"})
+		if err != nil {
+			t.Fatalf("Failed to create workitem: %s\n", err.Error())
+		}
+		if item.ID != "item_id" || item.Status != da.WorkItemPending || item.IsFinished() {
+			t.Errorf("Wrong created workitem: %#v", item)
+		}
+	})
+
+	t.Run("Get status and report of finished workitem", func(t *testing.T) {
+		item, err := daApi.GetWorkItemStatus("item_id")
+		if err != nil {
+			t.Fatalf("Failed to get workitem status: %s\n", err.Error())
+		}
+		if !item.IsFinished() {
+			t.Fatalf("WorkItem with status '%s' should be finished", item.Status)
+		}
+
+		report, err := daApi.GetWorkItemReport(item)
+		if err != nil {
+			t.Fatalf("Failed to get workitem report: %s\n", err.Error())
+		}
+		if string(report) != "report log" {
+			t.Errorf("Wrong report: %s", report)
+		}
+	})
+
+	t.Run("Get status of non-existing workitem", func(t *testing.T) {
+		_, err := daApi.GetWorkItemStatus("non_existing_workitem_id")
+		if _, ok := err.(*oauth.APIError); !ok || !oauth.IsNotFound(err) {
+			t.Fatalf("Expected a not found error, got %#v", err)
+		}
+	})
+
+	t.Run("Cancel workitem", func(t *testing.T) {
+		if err := daApi.CancelWorkItem("item_id"); err != nil {
+			t.Fatalf("Failed to cancel workitem: %s\n", err.Error())
+		}
+
+		err := daApi.CancelWorkItem("non_existing_workitem_id")
+		if _, ok := err.(*oauth.APIError); !ok || !oauth.IsNotFound(err) {
+			t.Errorf("Expected a not found error, got %#v", err)
+		}
+	})
+
+	t.Run("Get report of unfinished workitem", func(t *testing.T) {
+		item := da.WorkItem{ID: "some_id", Status: da.WorkItemPending}
+		_, err := daApi.GetWorkItemReport(item)
+		if err == nil {
+			t.Fatal("Getting report of unfinished workitem should fail, but it doesn't")
+		}
+	})
+}
+
+func TestWorkItem_DataParsing(t *testing.T) {
+
+	t.Run("Check WorkItem arguments built from Activity parameters", func(t *testing.T) {
+		param := da.Param{
+			Verb:      "get",
+			LocalName: "input.max",
+		}
+
+		config := da.WorkItemConfig{
+			ActivityID: "nickname.Activity+default",
+			Arguments: map[string]da.Argument{
+				"InputFile": param.Argument("https://example.com/input.max"),
+			},
+		}
+
+		data, err := json.Marshal(config)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		expected := `{"activityId":"nickname.Activity+default",` +
+			`"arguments":{"InputFile":{"url":"https://example.com/input.max","verb":"get","localName":"input.max"}}}`
+		if string(data) != expected {
+			t.Fatalf("Wrong workitem JSON:\nexpected %s\ngot      %s", expected, string(data))
+		}
+	})
+
+	t.Run("Check WorkItem status parsing", func(t *testing.T) {
+		statusJSON := `
+{
+    "status": "success",
+    "reportUrl": "https://dasprod-store.s3.amazonaws.com/workItem/nickname/report.txt",
+    "stats": {
+        "timeQueued": "2018-11-20T13:56:33.0453011Z",
+        "timeDownloadStarted": "2018-11-20T13:56:33.3267227Z",
+        "timeInstructionsStarted": "2018-11-20T13:56:33.8861521Z",
+        "timeInstructionsEnded": "2018-11-20T13:56:46.2236069Z",
+        "timeUploadEnded": "2018-11-20T13:56:46.5989151Z",
+        "bytesDownloaded": 193536,
+        "bytesUploaded": 81592
+    },
+    "id": "3b2a5d8e1f9c4f1d9d1f1b6a0c2e3d4f"
+}`
+		result := da.WorkItem{}
+		err := json.Unmarshal([]byte(statusJSON), &result)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if !result.IsFinished() {
+			t.Fatalf("WorkItem with status '%s' should be finished", result.Status)
+		}
+
+		if len(result.ReportURL) == 0 {
+			t.Fatal("Could not extract the report URL")
+		}
+
+		if result.Stats.BytesDownloaded != 193536 {
+			t.Fatalf("Wrong parsing of stats: %+v", result.Stats)
+		}
+	})
+}
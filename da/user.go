@@ -1,30 +1,28 @@
 package da
 
 import (
-	"errors"
+	"context"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
 	"io/ioutil"
 	"net/http"
-	"strconv"
 	"strings"
 )
 
-func getUserID(path string, token string) (nickname string, err error) {
-	task := http.Client{}
+func getUserID(ctx context.Context, client oauth.Doer, path string, token string) (nickname string, err error) {
 	req, err := http.NewRequest("GET",
 		path+"/forgeapps/me",
 		nil,
 	)
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
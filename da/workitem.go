@@ -1 +1,200 @@
 package da
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io/ioutil"
+	"net/http"
+)
+
+// Statuses reported by Design Automation while and after processing a WorkItem
+const (
+	WorkItemPending                   = "pending"
+	WorkItemInProgress                = "inprogress"
+	WorkItemCancelled                 = "cancelled"
+	WorkItemFailedLimitDataSize       = "failedLimitDataSize"
+	WorkItemFailedLimitProcessingTime = "failedLimitProcessingTime"
+	WorkItemFailedDownload            = "failedDownload"
+	WorkItemFailedInstructions        = "failedInstructions"
+	WorkItemFailedUpload              = "failedUpload"
+	WorkItemFailedUploadOptional      = "failedUploadOptional"
+	WorkItemSuccess                   = "success"
+)
+
+// Argument describes how a WorkItem fills one of the Parameters declared by an Activity:
+// where the data comes from (or goes to) and under which name it is available to the engine.
+type Argument struct {
+	URL       string            `json:"url"`
+	Verb      string            `json:"verb,omitempty"`
+	LocalName string            `json:"localName,omitempty"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// WorkItemConfig contains the data necessary to be passed upon WorkItem creation
+// 	ActivityID - fully qualified id of the activity, g.e. "nickname.ActivityName+alias"
+// 	Arguments - keyed by the parameter names declared in ActivityConfig.Parameters
+type WorkItemConfig struct {
+	ActivityID string              `json:"activityId"`
+	Arguments  map[string]Argument `json:"arguments"`
+}
+
+// WorkItemStats reflects the timing statistics reported for a WorkItem
+type WorkItemStats struct {
+	TimeQueued              string `json:"timeQueued,omitempty"`
+	TimeDownloadStarted     string `json:"timeDownloadStarted,omitempty"`
+	TimeInstructionsStarted string `json:"timeInstructionsStarted,omitempty"`
+	TimeInstructionsEnded   string `json:"timeInstructionsEnded,omitempty"`
+	TimeUploadEnded         string `json:"timeUploadEnded,omitempty"`
+	TimeFinished            string `json:"timeFinished,omitempty"`
+	BytesDownloaded         uint64 `json:"bytesDownloaded,omitempty"`
+	BytesUploaded           uint64 `json:"bytesUploaded,omitempty"`
+}
+
+// WorkItem reflects the data received upon WorkItem creation and when querying its status.
+// 	The ReportURL is available only after WorkItem finished processing.
+type WorkItem struct {
+	ID        string        `json:"id"`
+	Status    string        `json:"status"`
+	Progress  string        `json:"progress,omitempty"`
+	ReportURL string        `json:"reportUrl,omitempty"`
+	Stats     WorkItemStats `json:"stats"`
+}
+
+// Argument builds a WorkItem Argument for this Param, reusing its Verb and LocalName,
+// so that the WorkItem matches what was declared in the ActivityConfig.
+func (param Param) Argument(url string) Argument {
+	return Argument{
+		URL:       url,
+		Verb:      param.Verb,
+		LocalName: param.LocalName,
+	}
+}
+
+// IsFinished reports if the WorkItem reached a final status, be it a success, a failure or a cancellation.
+func (item WorkItem) IsFinished() bool {
+	return item.Status != WorkItemPending && item.Status != WorkItemInProgress
+}
+
+/*
+ *	SUPPORT FUNCTIONS
+ */
+
+func createWorkItem(ctx context.Context, client oauth.Doer, path string, config WorkItemConfig, token string) (result WorkItem, err error) {
+
+	body, err := json.Marshal(config)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest("POST",
+		path+"/workitems",
+		bytes.NewReader(body),
+	)
+
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = oauth.NewAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+
+	err = decoder.Decode(&result)
+
+	return
+}
+
+func getWorkItemStatus(ctx context.Context, client oauth.Doer, path string, id string, token string) (result WorkItem, err error) {
+
+	req, err := http.NewRequest("GET",
+		path+"/workitems/"+id,
+		nil,
+	)
+
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = oauth.NewAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&result)
+
+	return
+}
+
+func cancelWorkItem(ctx context.Context, client oauth.Doer, path string, id string, token string) (err error) {
+
+	req, err := http.NewRequest("DELETE",
+		path+"/workitems/"+id,
+		nil,
+	)
+
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusNoContent {
+		err = oauth.NewAPIError(response)
+		return
+	}
+
+	return
+}
+
+// downloadReport fetches the report log, which is stored on a pre-signed URL and does not need a token
+func downloadReport(ctx context.Context, client oauth.Doer, reportURL string) (report []byte, err error) {
+
+	req, err := http.NewRequest("GET",
+		reportURL,
+		nil,
+	)
+
+	if err != nil {
+		return
+	}
+
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = oauth.NewAPIError(response)
+		return
+	}
+
+	report, err = ioutil.ReadAll(response.Body)
+
+	return
+}
@@ -2,12 +2,11 @@ package dm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/apprentice3d/forge-api-go-client/oauth"
-	"io/ioutil"
 	"net/http"
-	"strconv"
 )
 
 
@@ -17,19 +16,45 @@ func NewBucketAPI(authenticator oauth.ForgeAuthenticator) BucketAPI {
 	return BucketAPI{
 		authenticator,
 		"/oss/v2/buckets",
+		oauth.DefaultClient,
 	}
 }
 
 
-// CreateBucket creates and returns details of created bucket, or an error on failure
+// CreateBucket creates and returns details of created bucket, or an error on failure.
+// The bucket key and policy are validated before sending the request, see ValidateBucketKey and the Policy constants.
 func (api BucketAPI) CreateBucket(bucketKey, policyKey string) (result BucketDetails, err error) {
+	return api.CreateBucketContext(context.Background(), bucketKey, policyKey)
+}
+
+// CreateBucketContext is the same as CreateBucket, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) CreateBucketContext(ctx context.Context, bucketKey, policyKey string) (result BucketDetails, err error) {
+	return api.CreateBucketInRegionContext(ctx, bucketKey, Policy(policyKey), "")
+}
+
+// CreateBucketInRegion is the same as CreateBucket, but stores the bucket in given region instead of the default US one.
+func (api BucketAPI) CreateBucketInRegion(bucketKey string, policy Policy, region Region) (result BucketDetails, err error) {
+	return api.CreateBucketInRegionContext(context.Background(), bucketKey, policy, region)
+}
+
+// CreateBucketInRegionContext is the same as CreateBucketInRegion, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) CreateBucketInRegionContext(ctx context.Context, bucketKey string, policy Policy, region Region) (result BucketDetails, err error) {
+	if err = ValidateBucketKey(bucketKey); err != nil {
+		return
+	}
+	if err = policy.Validate(); err != nil {
+		return
+	}
+	if err = region.Validate(); err != nil {
+		return
+	}
 
-	bearer, err := api.Authenticator.GetToken("bucket:create")
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "bucket:create")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
-	result, err = createBucket(path, bucketKey, policyKey, bearer.AccessToken)
+	result, err = createBucket(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, policy, region, bearer.AccessToken)
 
 	return
 }
@@ -37,45 +62,129 @@ func (api BucketAPI) CreateBucket(bucketKey, policyKey string) (result BucketDet
 // DeleteBucket deletes bucket given its key.
 // 	WARNING: The bucket delete call is undocumented.
 func (api BucketAPI) DeleteBucket(bucketKey string) error {
-	bearer, err := api.Authenticator.GetToken("bucket:delete")
+	return api.DeleteBucketContext(context.Background(), bucketKey)
+}
+
+// DeleteBucketContext is the same as DeleteBucket, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) DeleteBucketContext(ctx context.Context, bucketKey string) error {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "bucket:delete")
 	if err != nil {
 		return err
 	}
 	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
 
-	return deleteBucket(path, bucketKey, bearer.AccessToken)
+	return deleteBucket(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, bearer.AccessToken)
 }
 
-// ListBuckets returns a list of all buckets created or associated with Forge secrets used for token creation
+// ListBuckets returns a list of all buckets created or associated with Forge secrets used for token creation.
+// The region, if given, is validated before sending the request, see the Region constants.
 func (api BucketAPI) ListBuckets(region, limit, startAt string) (result ListedBuckets, err error) {
-	bearer, err := api.Authenticator.GetToken("bucket:read")
+	return api.ListBucketsContext(context.Background(), region, limit, startAt)
+}
+
+// ListBucketsContext is the same as ListBuckets, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) ListBucketsContext(ctx context.Context, region, limit, startAt string) (result ListedBuckets, err error) {
+	if err = Region(region).Validate(); err != nil {
+		return
+	}
+
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "bucket:read")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath()+ api.BucketAPIPath
 
-	return listBuckets(path, region, limit, startAt, bearer.AccessToken)
+	return listBuckets(ctx, oauth.ClientOrDefault(api.Client), path, Region(region), limit, startAt, bearer.AccessToken)
 }
 
 // GetBucketDetails returns information associated to a bucket. See BucketDetails struct.
 func (api BucketAPI) GetBucketDetails(bucketKey string) (result BucketDetails, err error) {
-	bearer, err := api.Authenticator.GetToken("bucket:read")
+	return api.GetBucketDetailsContext(context.Background(), bucketKey)
+}
+
+// GetBucketDetailsContext is the same as GetBucketDetails, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) GetBucketDetailsContext(ctx context.Context, bucketKey string) (result BucketDetails, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "bucket:read")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
 
-	return getBucketDetails(path, bucketKey, bearer.AccessToken)
+	return getBucketDetails(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, bearer.AccessToken)
+}
+
+// GrantBucketAccess allows the application with given client id to read (BucketAccessRead) or to read and write
+// (BucketAccessFull) the objects of a bucket owned by the current application.
+func (api BucketAPI) GrantBucketAccess(bucketKey, clientID string, access BucketAccess) error {
+	return api.GrantBucketAccessContext(context.Background(), bucketKey, clientID, access)
 }
 
+// GrantBucketAccessContext is the same as GrantBucketAccess, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) GrantBucketAccessContext(ctx context.Context, bucketKey, clientID string, access BucketAccess) error {
+	if access != BucketAccessRead && access != BucketAccessFull {
+		return errors.New("invalid bucket access \"" + string(access) + "\": should be one of " +
+			string(BucketAccessRead) + " or " + string(BucketAccessFull))
+	}
+
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "bucket:update")
+	if err != nil {
+		return err
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	body := struct {
+		Allow []Permission `json:"allow"`
+	}{[]Permission{{clientID, access}}}
+
+	return updateBucketPermissions(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, "grant", body, bearer.AccessToken)
+}
+
+// RevokeBucketAccess removes the access to a bucket previously granted to the application with given client id.
+func (api BucketAPI) RevokeBucketAccess(bucketKey, clientID string) error {
+	return api.RevokeBucketAccessContext(context.Background(), bucketKey, clientID)
+}
+
+// RevokeBucketAccessContext is the same as RevokeBucketAccess, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) RevokeBucketAccessContext(ctx context.Context, bucketKey, clientID string) error {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "bucket:update")
+	if err != nil {
+		return err
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	body := struct {
+		Revoke []Permission `json:"revoke"`
+	}{[]Permission{{AuthID: clientID}}}
+
+	return updateBucketPermissions(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, "revoke", body, bearer.AccessToken)
+}
 
 
 /*
  *	SUPPORT FUNCTIONS
  */
-func getBucketDetails(path, bucketKey, token string) (result BucketDetails, err error) {
-	task := http.Client{}
 
+// newAPIError builds an APIError for a failed response, keeping the decoded ErrorResult as details
+func newAPIError(response *http.Response) *oauth.APIError {
+	apiErr := oauth.NewAPIError(response)
+
+	result := ErrorResult{}
+	if json.Unmarshal(apiErr.Body, &result) == nil && len(result.Reason) != 0 {
+		apiErr.Details = result
+	}
+
+	// the hubs, projects, folders, items and versions report the errors as JSON:API
+	errorList := JSONAPIErrors{}
+	if json.Unmarshal(apiErr.Body, &errorList) == nil && len(errorList.Errors) != 0 {
+		apiErr.Code = errorList.Errors[0].Code
+		apiErr.Reason = errorList.Errors[0].Detail
+		apiErr.Details = errorList
+	}
+
+	return apiErr
+}
+
+func getBucketDetails(ctx context.Context, client oauth.Doer, path, bucketKey, token string) (result BucketDetails, err error) {
 	req, err := http.NewRequest("GET",
 		path+"/"+bucketKey+"/details",
 		nil,
@@ -86,15 +195,14 @@ func getBucketDetails(path, bucketKey, token string) (result BucketDetails, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -104,9 +212,7 @@ func getBucketDetails(path, bucketKey, token string) (result BucketDetails, err
 	return
 }
 
-func listBuckets(path, region, limit, startAt, token string) (result ListedBuckets, err error) {
-	task := http.Client{}
-
+func listBuckets(ctx context.Context, client oauth.Doer, path string, region Region, limit, startAt, token string) (result ListedBuckets, err error) {
 	req, err := http.NewRequest("GET",
 		path,
 		nil,
@@ -118,7 +224,7 @@ func listBuckets(path, region, limit, startAt, token string) (result ListedBucke
 
 	params := req.URL.Query()
 	if len(region) != 0 {
-		params.Add("region", region)
+		params.Add("region", string(region))
 	}
 	if len(limit) != 0 {
 		params.Add("limit", limit)
@@ -130,33 +236,29 @@ func listBuckets(path, region, limit, startAt, token string) (result ListedBucke
 	req.URL.RawQuery = params.Encode()
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
 	decoder := json.NewDecoder(response.Body)
 	err = decoder.Decode(&result)
 
-	//TODO: address the pagination of buckets
 	return
 }
 
-func createBucket(path, bucketKey, policyKey, token string) (result BucketDetails, err error) {
-
-	task := http.Client{}
+func createBucket(ctx context.Context, client oauth.Doer, path, bucketKey string, policy Policy, region Region, token string) (result BucketDetails, err error) {
 
 	body, err := json.Marshal(
 		CreateBucketRequest{
 			bucketKey,
-			policyKey,
+			string(policy),
 		})
 	if err != nil {
 		return
@@ -172,15 +274,17 @@ func createBucket(path, bucketKey, policyKey, token string) (result BucketDetail
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	if len(region) != 0 {
+		req.Header.Set("x-ads-region", string(region))
+	}
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -191,9 +295,7 @@ func createBucket(path, bucketKey, policyKey, token string) (result BucketDetail
 	return
 }
 
-func deleteBucket(path, bucketKey, token string) (err error) {
-	task := http.Client{}
-
+func deleteBucket(ctx context.Context, client oauth.Doer, path, bucketKey, token string) (err error) {
 	req, err := http.NewRequest("DELETE",
 		path+"/"+bucketKey,
 		nil,
@@ -204,17 +306,47 @@ func deleteBucket(path, bucketKey, token string) (err error) {
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
 	return
 }
+
+// updateBucketPermissions sends the body to the grant or revoke endpoint of a bucket
+func updateBucketPermissions(ctx context.Context, client oauth.Doer, path, bucketKey, operation string, body interface{}, token string) (err error) {
+
+	byteParts, err := json.Marshal(body)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest("POST",
+		path+"/"+bucketKey+"/"+operation,
+		bytes.NewReader(byteParts),
+	)
+
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+	}
+
+	return
+}
@@ -1,47 +1,287 @@
 package dm
-//
-//type Hubs struct {
-//	Data    []Content `json:"data,omitempty"`
-//	JsonApi JsonAPI   `json:"jsonapi,omitempty"`
-//	Links   Link      `json:"links,omitempty"`
-//}
-//
-//type JsonAPI struct {
-//	Version string `json:"version,omitempty"`
-//}
-//
-//type Link struct {
-//	Self struct {
-//		Href string `json:"href,omitempty"`
-//	} `json:"self,omitempty"`
-//}
-//
-//type Content struct {
-//	Relationships struct {
-//		Projects Project `json:"projects,omitempty"`
-//	} `json:"relationships,omitempty"`
-//	Attributes Attribute `json:"attributes,omitempty"`
-//	Type       string    `json:"type,omitempty"`
-//	Id         string    `json:"id,omitempty"`
-//	Links      Link      `json:"links,omitempty"`
-//}
-//
-//type Project struct {
-//	Links struct {
-//		Related struct {
-//			Href string `json:"href,omitempty"`
-//		} `json:"related,omitempty"`
-//	} `json:"links,omitempty"`
-//}
-//
-//type Attribute struct {
-//	Name      string `json:"name,omitempty"`
-//	Extension struct {
-//		Data    map[string]interface{} `json:"data,omitempty"`
-//		Version string                 `json:"version,omitempty"`
-//		Type    string                 `json:"type,omitempty"`
-//		Schema  struct {
-//			Href string `json:"href,omitempty"`
-//		} `json:"schema,omitempty"`
-//	} `json:"extension,omitempty"`
-//}
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"net/url"
+)
+
+// SkipFolder can be returned by a FolderWalkFunc to avoid walking into the folder given to it,
+// or, when given an item, to skip the remaining contents of the folder holding it
+var SkipFolder = errors.New("skip this folder")
+
+// FolderWalkFunc is called by WalkFolder for each folder and item found, along with the path of its parent folder
+type FolderWalkFunc func(parentPath string, resource Resource) error
+
+// NewHubAPI returns a Hub API client with default configurations
+func NewHubAPI(authenticator oauth.ForgeAuthenticator) HubAPI {
+	return HubAPI{
+		authenticator,
+		"/project/v1",
+		"/data/v1",
+		oauth.DefaultClient,
+	}
+}
+
+// ListHubs returns the hubs accessible with the current token, following all the pages of the result.
+func (api HubAPI) ListHubs() (result ResourceList, err error) {
+	return api.ListHubsContext(context.Background())
+}
+
+// ListHubsContext is the same as ListHubs, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) ListHubsContext(ctx context.Context) (result ResourceList, err error) {
+	return api.list(ctx, api.projectPath("/hubs"))
+}
+
+// GetHub returns the details on a hub, given its id.
+func (api HubAPI) GetHub(hubID string) (result ResourceDocument, err error) {
+	return api.GetHubContext(context.Background(), hubID)
+}
+
+// GetHubContext is the same as GetHub, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) GetHubContext(ctx context.Context, hubID string) (result ResourceDocument, err error) {
+	return api.get(ctx, api.projectPath("/hubs/"+url.PathEscape(hubID)))
+}
+
+// ListProjects returns the projects of a hub, following all the pages of the result.
+func (api HubAPI) ListProjects(hubID string) (result ResourceList, err error) {
+	return api.ListProjectsContext(context.Background(), hubID)
+}
+
+// ListProjectsContext is the same as ListProjects, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) ListProjectsContext(ctx context.Context, hubID string) (result ResourceList, err error) {
+	return api.list(ctx, api.projectPath("/hubs/"+url.PathEscape(hubID)+"/projects"))
+}
+
+// GetProject returns the details on a project, given the ids of hub and project.
+func (api HubAPI) GetProject(hubID, projectID string) (result ResourceDocument, err error) {
+	return api.GetProjectContext(context.Background(), hubID, projectID)
+}
+
+// GetProjectContext is the same as GetProject, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) GetProjectContext(ctx context.Context, hubID, projectID string) (result ResourceDocument, err error) {
+	return api.get(ctx, api.projectPath("/hubs/"+url.PathEscape(hubID)+"/projects/"+url.PathEscape(projectID)))
+}
+
+// GetTopFolders returns the top level folders of a project, accessible to the current user.
+func (api HubAPI) GetTopFolders(hubID, projectID string) (result ResourceList, err error) {
+	return api.GetTopFoldersContext(context.Background(), hubID, projectID)
+}
+
+// GetTopFoldersContext is the same as GetTopFolders, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) GetTopFoldersContext(ctx context.Context, hubID, projectID string) (result ResourceList, err error) {
+	return api.list(ctx, api.projectPath("/hubs/"+url.PathEscape(hubID)+"/projects/"+url.PathEscape(projectID)+"/topFolders"))
+}
+
+// GetFolder returns the details on a folder, given the ids of project and folder.
+func (api HubAPI) GetFolder(projectID, folderID string) (result ResourceDocument, err error) {
+	return api.GetFolderContext(context.Background(), projectID, folderID)
+}
+
+// GetFolderContext is the same as GetFolder, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) GetFolderContext(ctx context.Context, projectID, folderID string) (result ResourceDocument, err error) {
+	return api.get(ctx, api.dataPath(projectID, "/folders/"+url.PathEscape(folderID)))
+}
+
+// ListFolderContents returns the folders and items found in a folder, following all the pages of the result.
+// The tip versions of the items are found in Included.
+func (api HubAPI) ListFolderContents(projectID, folderID string) (result ResourceList, err error) {
+	return api.ListFolderContentsContext(context.Background(), projectID, folderID)
+}
+
+// ListFolderContentsContext is the same as ListFolderContents, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) ListFolderContentsContext(ctx context.Context, projectID, folderID string) (result ResourceList, err error) {
+	return api.list(ctx, api.dataPath(projectID, "/folders/"+url.PathEscape(folderID)+"/contents"))
+}
+
+// GetItem returns the details on an item, the tip version being found in Included.
+func (api HubAPI) GetItem(projectID, itemID string) (result ResourceDocument, err error) {
+	return api.GetItemContext(context.Background(), projectID, itemID)
+}
+
+// GetItemContext is the same as GetItem, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) GetItemContext(ctx context.Context, projectID, itemID string) (result ResourceDocument, err error) {
+	return api.get(ctx, api.dataPath(projectID, "/items/"+url.PathEscape(itemID)))
+}
+
+// GetItemTip returns the latest version of an item.
+func (api HubAPI) GetItemTip(projectID, itemID string) (result ResourceDocument, err error) {
+	return api.GetItemTipContext(context.Background(), projectID, itemID)
+}
+
+// GetItemTipContext is the same as GetItemTip, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) GetItemTipContext(ctx context.Context, projectID, itemID string) (result ResourceDocument, err error) {
+	return api.get(ctx, api.dataPath(projectID, "/items/"+url.PathEscape(itemID)+"/tip"))
+}
+
+// ListVersions returns all the versions of an item, following all the pages of the result.
+func (api HubAPI) ListVersions(projectID, itemID string) (result ResourceList, err error) {
+	return api.ListVersionsContext(context.Background(), projectID, itemID)
+}
+
+// ListVersionsContext is the same as ListVersions, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) ListVersionsContext(ctx context.Context, projectID, itemID string) (result ResourceList, err error) {
+	return api.list(ctx, api.dataPath(projectID, "/items/"+url.PathEscape(itemID)+"/versions"))
+}
+
+// GetVersion returns the details on a version, given the ids of project and version.
+func (api HubAPI) GetVersion(projectID, versionID string) (result ResourceDocument, err error) {
+	return api.GetVersionContext(context.Background(), projectID, versionID)
+}
+
+// GetVersionContext is the same as GetVersion, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) GetVersionContext(ctx context.Context, projectID, versionID string) (result ResourceDocument, err error) {
+	return api.get(ctx, api.dataPath(projectID, "/versions/"+url.PathEscape(versionID)))
+}
+
+// WalkFolder walks the tree rooted at given folder, calling walkFn for each folder and item found.
+// If walkFn returns SkipFolder for a folder, its contents are not walked; if it returns SkipFolder for an item,
+// the remaining contents of the folder holding it are skipped. Any other error stops the walk.
+func (api HubAPI) WalkFolder(projectID, folderID string, walkFn FolderWalkFunc) error {
+	return api.WalkFolderContext(context.Background(), projectID, folderID, walkFn)
+}
+
+// WalkFolderContext is the same as WalkFolder, but takes a context allowing to cancel the requests or to set their deadline.
+func (api HubAPI) WalkFolderContext(ctx context.Context, projectID, folderID string, walkFn FolderWalkFunc) error {
+	return api.walkFolder(ctx, projectID, folderID, "", walkFn)
+}
+
+func (api HubAPI) walkFolder(ctx context.Context, projectID, folderID, folderPath string, walkFn FolderWalkFunc) error {
+	contents, err := api.ListFolderContentsContext(ctx, projectID, folderID)
+	if err != nil {
+		return err
+	}
+
+	for _, resource := range contents.Data {
+		err = walkFn(folderPath, resource)
+		if err == SkipFolder {
+			if resource.Type == "folders" {
+				continue
+			}
+			// as for filepath.Walk, skipping on an item skips the rest of its folder
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if resource.Type == "folders" {
+			name := resource.Attributes.DisplayName
+			if len(name) == 0 {
+				name = resource.Attributes.Name
+			}
+			if err = api.walkFolder(ctx, projectID, resource.ID, folderPath+"/"+name, walkFn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Identifier returns the resource identifier referred by a relationship to a single resource
+func (r Relationship) Identifier() (id ResourceIdentifier, ok bool) {
+	if r.Data == nil {
+		return
+	}
+	return *r.Data, true
+}
+
+// UnmarshalJSON decodes a relationship, accepting both a single resource and a list of resources as data
+func (r *Relationship) UnmarshalJSON(data []byte) error {
+	var content struct {
+		Data  json.RawMessage        `json:"data"`
+		Links *Links                 `json:"links"`
+		Meta  map[string]interface{} `json:"meta"`
+	}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+
+	*r = Relationship{Links: content.Links, Meta: content.Meta}
+
+	trimmed := bytes.TrimSpace(content.Data)
+	switch {
+	case len(trimmed) == 0 || bytes.Equal(trimmed, []byte("null")):
+		return nil
+	case trimmed[0] == '[':
+		return json.Unmarshal(trimmed, &r.DataList)
+	default:
+		r.Data = &ResourceIdentifier{}
+		return json.Unmarshal(trimmed, r.Data)
+	}
+}
+
+func (api HubAPI) projectPath(endpoint string) string {
+	return api.Authenticator.GetHostPath() + api.ProjectAPIPath + endpoint
+}
+
+func (api HubAPI) dataPath(projectID, endpoint string) string {
+	return api.Authenticator.GetHostPath() + api.DataAPIPath + "/projects/" + url.PathEscape(projectID) + endpoint
+}
+
+func (api HubAPI) get(ctx context.Context, path string) (result ResourceDocument, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
+	if err != nil {
+		return
+	}
+
+	err = getJSONAPI(ctx, oauth.ClientOrDefault(api.Client), path, bearer.AccessToken, &result)
+
+	return
+}
+
+// list requests the collection and all its next pages, gathering the resources into one result
+func (api HubAPI) list(ctx context.Context, path string) (result ResourceList, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
+	if err != nil {
+		return
+	}
+	client := oauth.ClientOrDefault(api.Client)
+
+	for next := path; len(next) != 0; next = result.Links.Next.Href {
+		var page ResourceList
+		if err = getJSONAPI(ctx, client, next, bearer.AccessToken, &page); err != nil {
+			return
+		}
+
+		page.Data = append(result.Data, page.Data...)
+		page.Included = append(result.Included, page.Included...)
+		result = page
+	}
+
+	return
+}
+
+/*
+ *	SUPPORT FUNCTIONS
+ */
+
+func getJSONAPI(ctx context.Context, client oauth.Doer, path, token string, result interface{}) (err error) {
+
+	req, err := http.NewRequest("GET", path, nil)
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(result)
+
+	return
+}
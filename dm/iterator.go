@@ -0,0 +1,156 @@
+package dm
+
+import (
+	"context"
+	"net/url"
+)
+
+// BucketIterator walks through the buckets returned by ListBuckets, requesting the next page when needed.
+// 	Use it as:
+// 	it := api.Buckets("", "")
+// 	for it.Next() {
+// 		bucket := it.Bucket()
+// 	}
+// 	if it.Err() != nil {...}
+// 	The iteration can be stopped at any time, no more pages being requested.
+type BucketIterator struct {
+	api           BucketAPI
+	ctx           context.Context
+	region, limit string
+
+	page    pageCursor
+	items   []BucketItem
+	current BucketItem
+}
+
+// ObjectIterator walks through the objects of a bucket returned by ListObjects, requesting the next page when needed.
+// It is used the same way as BucketIterator.
+type ObjectIterator struct {
+	api                          BucketAPI
+	ctx                          context.Context
+	bucketKey, limit, beginsWith string
+
+	page    pageCursor
+	items   []ObjectDetails
+	current ObjectDetails
+}
+
+// pageCursor tracks the position in a paginated listing, shared by the iterators
+type pageCursor struct {
+	startAt string
+	started bool
+	done    bool
+	err     error
+}
+
+// Buckets returns an iterator over all buckets in given region (empty for default), requesting them in pages of limit size.
+func (api BucketAPI) Buckets(region, limit string) *BucketIterator {
+	return api.BucketsContext(context.Background(), region, limit)
+}
+
+// BucketsContext is the same as Buckets, but takes a context allowing to cancel the requests or to set their deadline.
+func (api BucketAPI) BucketsContext(ctx context.Context, region, limit string) *BucketIterator {
+	return &BucketIterator{api: api, ctx: ctx, region: region, limit: limit}
+}
+
+// Next advances to the next bucket, returning false when there are no more buckets or an error occurred.
+func (it *BucketIterator) Next() bool {
+	for len(it.items) == 0 {
+		if !it.page.advance() {
+			return false
+		}
+
+		list, err := it.api.ListBucketsContext(it.ctx, it.region, it.limit, it.page.startAt)
+		if !it.page.update(list.Next, err) {
+			return false
+		}
+		it.items = list.Items
+	}
+
+	it.current, it.items = it.items[0], it.items[1:]
+
+	return true
+}
+
+// Bucket returns the current bucket, valid after Next returned true
+func (it *BucketIterator) Bucket() BucketItem {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *BucketIterator) Err() error {
+	return it.page.err
+}
+
+// Objects returns an iterator over all objects in a bucket having names starting with beginsWith (empty for all),
+// requesting them in pages of limit size.
+func (api BucketAPI) Objects(bucketKey, limit, beginsWith string) *ObjectIterator {
+	return api.ObjectsContext(context.Background(), bucketKey, limit, beginsWith)
+}
+
+// ObjectsContext is the same as Objects, but takes a context allowing to cancel the requests or to set their deadline.
+func (api BucketAPI) ObjectsContext(ctx context.Context, bucketKey, limit, beginsWith string) *ObjectIterator {
+	return &ObjectIterator{api: api, ctx: ctx, bucketKey: bucketKey, limit: limit, beginsWith: beginsWith}
+}
+
+// Next advances to the next object, returning false when there are no more objects or an error occurred.
+func (it *ObjectIterator) Next() bool {
+	for len(it.items) == 0 {
+		if !it.page.advance() {
+			return false
+		}
+
+		content, err := it.api.ListObjectsContext(it.ctx, it.bucketKey, it.limit, it.beginsWith, it.page.startAt)
+		if !it.page.update(content.Next, err) {
+			return false
+		}
+		it.items = content.Items
+	}
+
+	it.current, it.items = it.items[0], it.items[1:]
+
+	return true
+}
+
+// Object returns the current object, valid after Next returned true
+func (it *ObjectIterator) Object() ObjectDetails {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any
+func (it *ObjectIterator) Err() error {
+	return it.page.err
+}
+
+// advance reports if another page should be requested
+func (c *pageCursor) advance() bool {
+	if c.done || c.err != nil {
+		return false
+	}
+	if c.started && len(c.startAt) == 0 {
+		c.done = true
+		return false
+	}
+	c.started = true
+	return true
+}
+
+// update records the result of a page request, extracting the startAt of next page from its URL
+func (c *pageCursor) update(next string, err error) bool {
+	if err != nil {
+		c.err = err
+		return false
+	}
+
+	c.startAt = ""
+	if len(next) != 0 {
+		nextURL, err := url.Parse(next)
+		if err != nil {
+			c.err = err
+			return false
+		}
+		c.startAt = nextURL.Query().Get("startAt")
+	}
+
+	return true
+}
@@ -2,10 +2,14 @@ package dm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io"
 	"io/ioutil"
 	"net/http"
+	"os"
 	"strconv"
 )
 
@@ -14,36 +18,282 @@ import (
 // UploadObject adds to specified bucket the given data (can originate from a multipart-form or direct file read).
 // Return details on uploaded object, including the object URN. Check ObjectDetails struct.
 func (api BucketAPI) UploadObject(bucketKey string, objectName string, data []byte) (result ObjectDetails, err error) {
-	bearer, err := api.Authenticator.GetToken("data:write")
+	return api.UploadObjectContext(context.Background(), bucketKey, objectName, data)
+}
+
+// UploadObjectContext is the same as UploadObject, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) UploadObjectContext(ctx context.Context, bucketKey string, objectName string, data []byte) (result ObjectDetails, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	return uploadObject(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName,
+		bytes.NewReader(data), int64(len(data)), bearer.AccessToken)
+}
+
+// UploadObjectFrom adds to specified bucket the data read from given reader, without loading it in memory.
+// The size of data should be known in advance, g.e. from file info.
+func (api BucketAPI) UploadObjectFrom(bucketKey, objectName string, data io.Reader, size int64) (result ObjectDetails, err error) {
+	return api.UploadObjectFromContext(context.Background(), bucketKey, objectName, data, size)
+}
+
+// UploadObjectFromContext is the same as UploadObjectFrom, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) UploadObjectFromContext(ctx context.Context, bucketKey, objectName string, data io.Reader, size int64) (result ObjectDetails, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
 
-	return uploadObject(path, bucketKey, objectName, data, bearer.AccessToken)
+	return uploadObject(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, data, size, bearer.AccessToken)
+}
+
+// UploadFile adds to specified bucket the content of the file found at given path, streaming it from disk.
+func (api BucketAPI) UploadFile(bucketKey, objectName, filePath string) (result ObjectDetails, err error) {
+	return api.UploadFileContext(context.Background(), bucketKey, objectName, filePath)
+}
+
+// UploadFileContext is the same as UploadFile, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) UploadFileContext(ctx context.Context, bucketKey, objectName, filePath string) (result ObjectDetails, err error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return
+	}
+
+	return api.UploadObjectFromContext(ctx, bucketKey, objectName, file, info.Size())
 }
 
 // ListObjects returns the bucket contains along with details on each item.
 func (api BucketAPI) ListObjects(bucketKey, limit, beginsWith, startAt string) (result BucketContent, err error) {
-	bearer, err := api.Authenticator.GetToken("data:read")
+	return api.ListObjectsContext(context.Background(), bucketKey, limit, beginsWith, startAt)
+}
+
+// ListObjectsContext is the same as ListObjects, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) ListObjectsContext(ctx context.Context, bucketKey, limit, beginsWith, startAt string) (result BucketContent, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
 
-	return listObjects(path, bucketKey, limit, beginsWith, startAt, bearer.AccessToken)
+	return listObjects(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, limit, beginsWith, startAt, bearer.AccessToken)
 }
 
 
 // DownloadObject downloads an on object, given the URL-encoded object name.
 func (api BucketAPI) DownloadObject(bucketKey string, objectName string) (result []byte, err error) {
-	bearer, err := api.Authenticator.GetToken("data:read")
+	return api.DownloadObjectContext(context.Background(), bucketKey, objectName)
+}
+
+// DownloadObjectContext is the same as DownloadObject, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) DownloadObjectContext(ctx context.Context, bucketKey string, objectName string) (result []byte, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	return downloadObject(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName,  bearer.AccessToken)
+}
+
+// OpenObject starts downloading an object, given the URL-encoded object name, and returns its content as a stream.
+// 	Note: the caller is responsible for closing the returned reader.
+func (api BucketAPI) OpenObject(bucketKey, objectName string) (content io.ReadCloser, err error) {
+	return api.OpenObjectContext(context.Background(), bucketKey, objectName)
+}
+
+// OpenObjectContext is the same as OpenObject, but takes a context allowing to cancel the request or to set its deadline.
+// The context applies also to reading the returned content.
+func (api BucketAPI) OpenObjectContext(ctx context.Context, bucketKey, objectName string) (content io.ReadCloser, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	return openObject(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, bearer.AccessToken)
+}
+
+// DownloadObjectTo downloads an object, given the URL-encoded object name, copying its content into given writer.
+// Returns the number of written bytes.
+func (api BucketAPI) DownloadObjectTo(bucketKey, objectName string, writer io.Writer) (written int64, err error) {
+	return api.DownloadObjectToContext(context.Background(), bucketKey, objectName, writer)
+}
+
+// DownloadObjectToContext is the same as DownloadObjectTo, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) DownloadObjectToContext(ctx context.Context, bucketKey, objectName string, writer io.Writer) (written int64, err error) {
+	content, err := api.OpenObjectContext(ctx, bucketKey, objectName)
+	if err != nil {
+		return
+	}
+	defer content.Close()
+
+	return io.Copy(writer, content)
+}
+
+// DownloadFile downloads an object, given the URL-encoded object name, into a file created at given path.
+// Returns the number of written bytes.
+func (api BucketAPI) DownloadFile(bucketKey, objectName, filePath string) (written int64, err error) {
+	return api.DownloadFileContext(context.Background(), bucketKey, objectName, filePath)
+}
+
+// DownloadFileContext is the same as DownloadFile, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) DownloadFileContext(ctx context.Context, bucketKey, objectName, filePath string) (written int64, err error) {
+	content, err := api.OpenObjectContext(ctx, bucketKey, objectName)
+	if err != nil {
+		return
+	}
+	defer content.Close()
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return
+	}
+
+	written, err = io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// do not leave a truncated file behind
+		os.Remove(filePath)
+	}
+
+	return
+}
+
+// DownloadObjectRange downloads a part of an object, given the URL-encoded object name and the byte range.
+// Both start and end are inclusive, a negative end meaning until the end of the object.
+// 	An error is returned when the service ignores the range and answers with the whole object.
+func (api BucketAPI) DownloadObjectRange(bucketKey, objectName string, start, end int64) (result []byte, err error) {
+	return api.DownloadObjectRangeContext(context.Background(), bucketKey, objectName, start, end)
+}
+
+// DownloadObjectRangeContext is the same as DownloadObjectRange, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) DownloadObjectRangeContext(ctx context.Context, bucketKey, objectName string, start, end int64) (result []byte, err error) {
+	if start < 0 || (end >= 0 && end < start) {
+		err = errors.New("invalid byte range " + strconv.FormatInt(start, 10) + "-" + strconv.FormatInt(end, 10))
+		return
+	}
+
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	byteRange := "bytes=" + strconv.FormatInt(start, 10) + "-"
+	if end >= 0 {
+		byteRange += strconv.FormatInt(end, 10)
+	}
+
+	content, err := openObjectRange(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, byteRange, bearer.AccessToken)
+	if err != nil {
+		return
+	}
+	defer content.Close()
+
+	return ioutil.ReadAll(content)
+}
+
+// GetObjectDetails returns details on an object, given the URL-encoded object name.
+func (api BucketAPI) GetObjectDetails(bucketKey, objectName string) (result ObjectDetails, err error) {
+	return api.GetObjectDetailsContext(context.Background(), bucketKey, objectName)
+}
+
+// GetObjectDetailsContext is the same as GetObjectDetails, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) GetObjectDetailsContext(ctx context.Context, bucketKey, objectName string) (result ObjectDetails, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
 
-	return downloadObject(path, bucketKey, objectName,  bearer.AccessToken)
+	return getObjectDetails(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, bearer.AccessToken)
+}
+
+// DeleteObject deletes an object, given the URL-encoded object name.
+func (api BucketAPI) DeleteObject(bucketKey, objectName string) error {
+	return api.DeleteObjectContext(context.Background(), bucketKey, objectName)
+}
+
+// DeleteObjectContext is the same as DeleteObject, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) DeleteObjectContext(ctx context.Context, bucketKey, objectName string) error {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
+	if err != nil {
+		return err
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	return deleteObject(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, bearer.AccessToken)
+}
+
+// CopyObject copies an object to another key, in the same or another bucket, and returns details on the copy.
+// 	Note: OSS copies only within a bucket, thus a copy to another bucket is streamed through the client.
+func (api BucketAPI) CopyObject(bucketKey, objectName, targetBucketKey, targetObjectName string) (result ObjectDetails, err error) {
+	return api.CopyObjectContext(context.Background(), bucketKey, objectName, targetBucketKey, targetObjectName)
+}
+
+// CopyObjectContext is the same as CopyObject, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) CopyObjectContext(ctx context.Context, bucketKey, objectName, targetBucketKey, targetObjectName string) (result ObjectDetails, err error) {
+	if targetBucketKey != bucketKey {
+		return api.copyObjectAcross(ctx, bucketKey, objectName, targetBucketKey, targetObjectName)
+	}
+
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read data:write")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	return copyObject(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, targetObjectName, bearer.AccessToken)
+}
+
+// RenameObject moves an object to another key within the same bucket, by copying it and deleting the original.
+// 	Renaming an object to its own key is rejected, as the copy would be deleted along with the original.
+func (api BucketAPI) RenameObject(bucketKey, objectName, newObjectName string) (result ObjectDetails, err error) {
+	return api.RenameObjectContext(context.Background(), bucketKey, objectName, newObjectName)
+}
+
+// RenameObjectContext is the same as RenameObject, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) RenameObjectContext(ctx context.Context, bucketKey, objectName, newObjectName string) (result ObjectDetails, err error) {
+	if newObjectName == objectName {
+		err = errors.New("cannot rename the object " + objectName + " to itself")
+		return
+	}
+
+	result, err = api.CopyObjectContext(ctx, bucketKey, objectName, bucketKey, newObjectName)
+	if err != nil {
+		return
+	}
+
+	err = api.DeleteObjectContext(ctx, bucketKey, objectName)
+
+	return
+}
+
+// copyObjectAcross streams the object from one bucket into another, as OSS cannot copy between buckets
+func (api BucketAPI) copyObjectAcross(ctx context.Context, bucketKey, objectName, targetBucketKey, targetObjectName string) (result ObjectDetails, err error) {
+	details, err := api.GetObjectDetailsContext(ctx, bucketKey, objectName)
+	if err != nil {
+		return
+	}
+
+	content, err := api.OpenObjectContext(ctx, bucketKey, objectName)
+	if err != nil {
+		return
+	}
+	defer content.Close()
+
+	return api.UploadObjectFromContext(ctx, targetBucketKey, targetObjectName, content, int64(details.Size))
 }
 
 
@@ -51,9 +301,7 @@ func (api BucketAPI) DownloadObject(bucketKey string, objectName string) (result
  *	SUPPORT FUNCTIONS
  */
 
-func listObjects(path, bucketKey, limit, beginsWith, startAt, token string) (result BucketContent, err error) {
-	task := http.Client{}
-
+func listObjects(ctx context.Context, client oauth.Doer, path, bucketKey, limit, beginsWith, startAt, token string) (result BucketContent, err error) {
 	req, err := http.NewRequest("GET",
 		path + "/" + bucketKey + "/objects",
 		nil,
@@ -77,15 +325,14 @@ func listObjects(path, bucketKey, limit, beginsWith, startAt, token string) (res
 	req.URL.RawQuery = params.Encode()
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -95,21 +342,23 @@ func listObjects(path, bucketKey, limit, beginsWith, startAt, token string) (res
 	return
 }
 
-func uploadObject(path, bucketKey, objectName string, data []byte, token string) (result ObjectDetails, err error) {
-
-	task := http.Client{}
+func uploadObject(ctx context.Context, client oauth.Doer, path, bucketKey, objectName string, data io.Reader, size int64, token string) (result ObjectDetails, err error) {
 
-	dataContent := bytes.NewReader(data)
 	req, err := http.NewRequest("PUT",
 		path+"/"+ bucketKey + "/objects/" + objectName,
-		dataContent)
+		data)
 
 	if err != nil {
 		return
 	}
+	req.ContentLength = size
+	if size == 0 {
+		// an empty body of unknown length would be sent chunked, which OSS rejects
+		req.Body = http.NoBody
+	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return
@@ -117,8 +366,7 @@ func uploadObject(path, bucketKey, objectName string, data []byte, token string)
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -129,9 +377,25 @@ func uploadObject(path, bucketKey, objectName string, data []byte, token string)
 
 }
 
-func downloadObject(path, bucketKey, objectName string, token string) (result []byte, err error) {
+func downloadObject(ctx context.Context, client oauth.Doer, path, bucketKey, objectName string, token string) (result []byte, err error) {
+
+	content, err := openObject(ctx, client, path, bucketKey, objectName, token)
+	if err != nil {
+		return
+	}
+	defer content.Close()
+
+	result, err = ioutil.ReadAll(content)
+
+	return
+
+}
+
+func openObject(ctx context.Context, client oauth.Doer, path, bucketKey, objectName string, token string) (content io.ReadCloser, err error) {
+	return openObjectRange(ctx, client, path, bucketKey, objectName, "", token)
+}
 
-	task := http.Client{}
+func openObjectRange(ctx context.Context, client oauth.Doer, path, bucketKey, objectName, byteRange string, token string) (content io.ReadCloser, err error) {
 
 	req, err := http.NewRequest("GET",
 		path+"/"+ bucketKey + "/objects/" + objectName,
@@ -142,21 +406,115 @@ func downloadObject(path, bucketKey, objectName string, token string) (result []
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	if len(byteRange) != 0 {
+		req.Header.Set("Range", byteRange)
+	}
+	response, err := client.Do(req.WithContext(ctx))
+
+	if err != nil {
+		return
+	}
+
+	expected := http.StatusOK
+	if len(byteRange) != 0 {
+		expected = http.StatusPartialContent
+	}
+
+	if response.StatusCode == http.StatusOK && expected == http.StatusPartialContent {
+		// the range was ignored, do not hand the whole object as the requested part
+		response.Body.Close()
+		err = errors.New("the range " + byteRange + " was not honored, got the whole object")
+		return
+	}
+
+	if response.StatusCode != expected {
+		defer response.Body.Close()
+		err = newAPIError(response)
+		return
+	}
+
+	content = response.Body
+
+	return
+
+}
 
+func getObjectDetails(ctx context.Context, client oauth.Doer, path, bucketKey, objectName, token string) (result ObjectDetails, err error) {
+
+	req, err := http.NewRequest("GET",
+		path+"/"+bucketKey+"/objects/"+objectName+"/details",
+		nil)
+
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&result)
+
+	return
+}
+
+func deleteObject(ctx context.Context, client oauth.Doer, path, bucketKey, objectName, token string) (err error) {
+
+	req, err := http.NewRequest("DELETE",
+		path+"/"+bucketKey+"/objects/"+objectName,
+		nil)
+
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
 		return
 	}
+	defer response.Body.Close()
 
-	result,err = ioutil.ReadAll(response.Body)
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+	}
 
 	return
+}
+
+func copyObject(ctx context.Context, client oauth.Doer, path, bucketKey, objectName, newObjectName, token string) (result ObjectDetails, err error) {
+
+	req, err := http.NewRequest("PUT",
+		path+"/"+bucketKey+"/objects/"+objectName+"/copyto/"+newObjectName,
+		nil)
 
-}
\ No newline at end of file
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&result)
+
+	return
+}
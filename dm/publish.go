@@ -0,0 +1,244 @@
+package dm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// storageIDPrefix precedes the bucket key and object name in the id of a storage location
+const storageIDPrefix = "urn:adsk.objects:os.object:"
+
+// CreateStorage creates a storage location in OSS for a file to be added to given folder.
+// The id of the returned resource is used to upload the file and to create its item or version.
+func (api HubAPI) CreateStorage(projectID, folderID, fileName string) (result ResourceDocument, err error) {
+	return api.CreateStorageContext(context.Background(), projectID, folderID, fileName)
+}
+
+// CreateStorageContext is the same as CreateStorage, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) CreateStorageContext(ctx context.Context, projectID, folderID, fileName string) (result ResourceDocument, err error) {
+	storage := Resource{
+		Type:       "objects",
+		Attributes: Attributes{Name: fileName},
+		Relationships: map[string]Relationship{
+			"target": {Data: &ResourceIdentifier{"folders", folderID}},
+		},
+	}
+
+	err = api.post(ctx, api.dataPath(projectID, "/storage"), storage, nil, &result)
+
+	return
+}
+
+// CreateItem creates in given folder an item having as first version the file uploaded to the storage location.
+// The id of the created version is found in Included.
+func (api HubAPI) CreateItem(projectID, folderID, fileName, storageID string) (result ResourceDocument, err error) {
+	return api.CreateItemContext(context.Background(), projectID, folderID, fileName, storageID)
+}
+
+// CreateItemContext is the same as CreateItem, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) CreateItemContext(ctx context.Context, projectID, folderID, fileName, storageID string) (result ResourceDocument, err error) {
+	itemType, versionType := fileExtensionTypes(projectID)
+
+	item := Resource{
+		Type: "items",
+		Attributes: Attributes{
+			DisplayName: fileName,
+			Extension:   &Extension{Type: itemType, Version: "1.0"},
+		},
+		Relationships: map[string]Relationship{
+			"tip":    {Data: &ResourceIdentifier{"versions", "1"}},
+			"parent": {Data: &ResourceIdentifier{"folders", folderID}},
+		},
+	}
+	version := Resource{
+		Type: "versions",
+		ID:   "1",
+		Attributes: Attributes{
+			Name:      fileName,
+			Extension: &Extension{Type: versionType, Version: "1.0"},
+		},
+		Relationships: map[string]Relationship{
+			"storage": {Data: &ResourceIdentifier{"objects", storageID}},
+		},
+	}
+
+	err = api.post(ctx, api.dataPath(projectID, "/items"), item, []Resource{version}, &result)
+
+	return
+}
+
+// CreateVersion adds to an existing item a new version, given by the file uploaded to the storage location.
+func (api HubAPI) CreateVersion(projectID, itemID, fileName, storageID string) (result ResourceDocument, err error) {
+	return api.CreateVersionContext(context.Background(), projectID, itemID, fileName, storageID)
+}
+
+// CreateVersionContext is the same as CreateVersion, but takes a context allowing to cancel the request or to set its deadline.
+func (api HubAPI) CreateVersionContext(ctx context.Context, projectID, itemID, fileName, storageID string) (result ResourceDocument, err error) {
+	_, versionType := fileExtensionTypes(projectID)
+
+	version := Resource{
+		Type: "versions",
+		Attributes: Attributes{
+			Name:      fileName,
+			Extension: &Extension{Type: versionType, Version: "1.0"},
+		},
+		Relationships: map[string]Relationship{
+			"item":    {Data: &ResourceIdentifier{"items", itemID}},
+			"storage": {Data: &ResourceIdentifier{"objects", storageID}},
+		},
+	}
+
+	err = api.post(ctx, api.dataPath(projectID, "/versions"), version, nil, &result)
+
+	return
+}
+
+// PublishFile uploads the data as a file in given folder and returns the id of the created version.
+// 	If the folder already contains an item with the same name, a new version of it is created,
+// 	otherwise a new item is created with the uploaded file as its first version.
+func (api HubAPI) PublishFile(projectID, folderID, fileName string, data io.Reader, size int64) (versionID string, err error) {
+	return api.PublishFileContext(context.Background(), projectID, folderID, fileName, data, size)
+}
+
+// PublishFileContext is the same as PublishFile, but takes a context allowing to cancel the requests or to set their deadline.
+func (api HubAPI) PublishFileContext(ctx context.Context, projectID, folderID, fileName string, data io.Reader, size int64) (versionID string, err error) {
+	storage, err := api.CreateStorageContext(ctx, projectID, folderID, fileName)
+	if err != nil {
+		return
+	}
+
+	bucketKey, objectName, err := parseStorageID(storage.Data.ID)
+	if err != nil {
+		return
+	}
+
+	bucketAPI := BucketAPI{api.Authenticator, "/oss/v2/buckets", api.Client}
+	if _, err = bucketAPI.UploadObjectFromContext(ctx, bucketKey, objectName, data, size); err != nil {
+		return
+	}
+
+	itemID, err := api.findItem(ctx, projectID, folderID, fileName)
+	if err != nil {
+		return
+	}
+
+	if len(itemID) != 0 {
+		var version ResourceDocument
+		version, err = api.CreateVersionContext(ctx, projectID, itemID, fileName, storage.Data.ID)
+		versionID = version.Data.ID
+		return
+	}
+
+	item, err := api.CreateItemContext(ctx, projectID, folderID, fileName, storage.Data.ID)
+	if err != nil {
+		return
+	}
+
+	for _, included := range item.Included {
+		if included.Type == "versions" {
+			return included.ID, nil
+		}
+	}
+	if tip, ok := item.Data.Relationships["tip"].Identifier(); ok {
+		return tip.ID, nil
+	}
+
+	err = errors.New("the created item " + item.Data.ID + " does not refer to its version")
+
+	return
+}
+
+// findItem returns the id of the item with given name in the folder, or an empty id if there is none
+func (api HubAPI) findItem(ctx context.Context, projectID, folderID, fileName string) (itemID string, err error) {
+	contents, err := api.ListFolderContentsContext(ctx, projectID, folderID)
+	if err != nil {
+		return
+	}
+
+	for _, resource := range contents.Data {
+		if resource.Type == "items" && resource.Attributes.DisplayName == fileName {
+			return resource.ID, nil
+		}
+	}
+
+	return
+}
+
+func (api HubAPI) post(ctx context.Context, path string, data Resource, included []Resource, result interface{}) (err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:create data:write")
+	if err != nil {
+		return
+	}
+
+	body := struct {
+		JSONAPI  JSONAPI    `json:"jsonapi"`
+		Data     Resource   `json:"data"`
+		Included []Resource `json:"included,omitempty"`
+	}{JSONAPI{"1.0"}, data, included}
+
+	return postJSONAPI(ctx, oauth.ClientOrDefault(api.Client), path, body, bearer.AccessToken, result)
+}
+
+// fileExtensionTypes returns the extension types of items and versions for files,
+// BIM 360 and ACC projects having ids starting with "b."
+func fileExtensionTypes(projectID string) (itemType, versionType string) {
+	if strings.HasPrefix(projectID, "b.") {
+		return "items:autodesk.bim360:File", "versions:autodesk.bim360:File"
+	}
+	return "items:autodesk.core:File", "versions:autodesk.core:File"
+}
+
+// parseStorageID extracts the bucket key and object name from a storage id,
+// like "urn:adsk.objects:os.object:wip.dm.prod/2a6d61f2-49df-4d7b-9aed-439586d61df7.rvt"
+func parseStorageID(storageID string) (bucketKey, objectName string, err error) {
+	location := strings.TrimPrefix(storageID, storageIDPrefix)
+	separator := strings.Index(location, "/")
+	if location == storageID || separator <= 0 || separator == len(location)-1 {
+		err = errors.New("unexpected storage id: " + storageID)
+		return
+	}
+
+	return location[:separator], url.PathEscape(location[separator+1:]), nil
+}
+
+/*
+ *	SUPPORT FUNCTIONS
+ */
+
+func postJSONAPI(ctx context.Context, client oauth.Doer, path string, body interface{}, token string, result interface{}) (err error) {
+
+	byteParts, err := json.Marshal(body)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest("POST", path, bytes.NewReader(byteParts))
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/vnd.api+json")
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(result)
+
+	return
+}
@@ -0,0 +1,285 @@
+package dm
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+const (
+	// MinChunkSize is the smallest chunk accepted by OSS, only the last chunk of an object can be smaller
+	MinChunkSize = 2 * 1024 * 1024
+	// DefaultChunkSize is used when a resumable upload is created without specifying the chunk size
+	DefaultChunkSize = 5 * 1024 * 1024
+)
+
+// ResumableUpload reflects the state of a chunked upload session. It can be persisted using Save
+// and restored with LoadResumableUpload to resume the upload after a restart, uploading only the missing chunks.
+// 	It is safe for concurrent use.
+type ResumableUpload struct {
+	BucketKey  string `json:"bucketKey"`
+	ObjectName string `json:"objectName"`
+	SessionID  string `json:"sessionId"`
+	Size       int64  `json:"size"`
+	ChunkSize  int64  `json:"chunkSize"`
+	Uploaded   []bool `json:"uploaded"` // Uploaded marks the chunks already received by the server
+
+	mutex sync.Mutex
+}
+
+// ResumableOptions specifies how the chunks of a ResumableUpload are sent
+type ResumableOptions struct {
+	Parallel int // How many chunks are uploaded at the same time, defaults to 1
+	// OnChunkUploaded, if set, is called after each uploaded chunk, g.e. to persist the upload state.
+	// 	The calls are not concurrent, even when uploading in parallel.
+	OnChunkUploaded func(upload *ResumableUpload, chunk int)
+}
+
+// NewResumableUpload creates the state of a new upload session for an object of given size, split into chunks of chunkSize bytes.
+// If chunkSize is not positive, the DefaultChunkSize is used.
+func NewResumableUpload(bucketKey, objectName string, size, chunkSize int64) (upload *ResumableUpload, err error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+	if chunkSize < MinChunkSize && chunkSize < size {
+		err = errors.New("chunk size should be at least " + strconv.Itoa(MinChunkSize) + " bytes")
+		return
+	}
+	if size <= 0 {
+		err = errors.New("cannot upload an empty object in chunks")
+		return
+	}
+
+	sessionID := make([]byte, 16)
+	if _, err = rand.Read(sessionID); err != nil {
+		return
+	}
+
+	upload = &ResumableUpload{
+		BucketKey:  bucketKey,
+		ObjectName: objectName,
+		SessionID:  hex.EncodeToString(sessionID),
+		Size:       size,
+		ChunkSize:  chunkSize,
+		Uploaded:   make([]bool, (size+chunkSize-1)/chunkSize),
+	}
+
+	return
+}
+
+// LoadResumableUpload restores the state of an upload session previously persisted with Save
+func LoadResumableUpload(reader io.Reader) (upload *ResumableUpload, err error) {
+	upload = &ResumableUpload{}
+	if err = json.NewDecoder(reader).Decode(upload); err != nil {
+		return nil, err
+	}
+
+	if upload.Size <= 0 || upload.ChunkSize <= 0 || len(upload.SessionID) == 0 ||
+		int64(len(upload.Uploaded)) != (upload.Size+upload.ChunkSize-1)/upload.ChunkSize {
+		return nil, errors.New("invalid resumable upload state")
+	}
+
+	return
+}
+
+// Save writes the state of the upload session as JSON, to be restored later with LoadResumableUpload
+func (u *ResumableUpload) Save(writer io.Writer) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	return json.NewEncoder(writer).Encode(u)
+}
+
+// Pending returns the indexes of chunks not uploaded yet
+func (u *ResumableUpload) Pending() (chunks []int) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	for idx, uploaded := range u.Uploaded {
+		if !uploaded {
+			chunks = append(chunks, idx)
+		}
+	}
+	return
+}
+
+// IsComplete reports if all the chunks were uploaded
+func (u *ResumableUpload) IsComplete() bool {
+	return len(u.Pending()) == 0
+}
+
+// chunkRange returns the byte range covered by given chunk, the end being inclusive as in Content-Range header
+func (u *ResumableUpload) chunkRange(chunk int) (start, end int64) {
+	start = int64(chunk) * u.ChunkSize
+	end = start + u.ChunkSize - 1
+	if end >= u.Size {
+		end = u.Size - 1
+	}
+	return
+}
+
+func (u *ResumableUpload) markUploaded(chunk int) {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	u.Uploaded[chunk] = true
+}
+
+// UploadResumable uploads the pending chunks of given session, reading them from data, and returns the details
+// on the object once the server received all the chunks.
+// 	Note: data should provide the same content each time the session is resumed, g.e. an *os.File of the uploaded file.
+// 	The token is requested for each chunk, thus consider using an oauth.CachedAuthenticator.
+func (api BucketAPI) UploadResumable(upload *ResumableUpload, data io.ReaderAt, options ResumableOptions) (result ObjectDetails, err error) {
+	return api.UploadResumableContext(context.Background(), upload, data, options)
+}
+
+// UploadResumableContext is the same as UploadResumable, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) UploadResumableContext(ctx context.Context, upload *ResumableUpload, data io.ReaderAt, options ResumableOptions) (result ObjectDetails, err error) {
+	pending := upload.Pending()
+	if len(pending) == 0 {
+		err = errors.New("all chunks of the upload session were already uploaded")
+		return
+	}
+
+	parallel := options.Parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	if parallel > len(pending) {
+		parallel = len(pending)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunks := make(chan int)
+	go func() {
+		defer close(chunks)
+		for _, chunk := range pending {
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var (
+		mutex     sync.Mutex
+		completed bool
+		firstErr  error
+		wg        sync.WaitGroup
+	)
+
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+	client := oauth.ClientOrDefault(api.Client)
+
+	for worker := 0; worker < parallel; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunks {
+				details, done, chunkErr := api.uploadResumableChunk(ctx, client, path, upload, chunk, data)
+
+				mutex.Lock()
+				if chunkErr != nil {
+					if firstErr == nil {
+						firstErr = chunkErr
+					}
+					mutex.Unlock()
+					cancel()
+					return
+				}
+				upload.markUploaded(chunk)
+				if done {
+					result = details
+					completed = true
+				}
+				if options.OnChunkUploaded != nil {
+					options.OnChunkUploaded(upload, chunk)
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		err = firstErr
+		return
+	}
+	if err = ctx.Err(); err != nil {
+		return
+	}
+	if !completed {
+		err = errors.New("the server did not confirm the completion of the upload session " + upload.SessionID)
+	}
+
+	return
+}
+
+func (api BucketAPI) uploadResumableChunk(ctx context.Context, client oauth.Doer, path string, upload *ResumableUpload, chunk int, data io.ReaderAt) (result ObjectDetails, completed bool, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
+	if err != nil {
+		return
+	}
+
+	start, end := upload.chunkRange(chunk)
+
+	return uploadChunk(ctx, client, path, upload.BucketKey, upload.ObjectName, upload.SessionID,
+		data, start, end, upload.Size, bearer.AccessToken)
+}
+
+/*
+ *	SUPPORT FUNCTIONS
+ */
+
+func uploadChunk(ctx context.Context, client oauth.Doer, path, bucketKey, objectName, sessionID string, data io.ReaderAt, start, end, total int64, token string) (result ObjectDetails, completed bool, err error) {
+
+	size := end - start + 1
+	req, err := http.NewRequest("PUT",
+		path+"/"+bucketKey+"/objects/"+objectName+"/resumable",
+		io.NewSectionReader(data, start, size))
+
+	if err != nil {
+		return
+	}
+	req.ContentLength = size
+	// allows replaying the chunk when the request is retried
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(io.NewSectionReader(data, start, size)), nil
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Range", "bytes "+strconv.FormatInt(start, 10)+"-"+
+		strconv.FormatInt(end, 10)+"/"+strconv.FormatInt(total, 10))
+	req.Header.Set("Session-Id", sessionID)
+
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusAccepted:
+		// the chunk was received, but other chunks are still missing
+		return
+	case http.StatusOK:
+		completed = true
+		err = json.NewDecoder(response.Body).Decode(&result)
+	default:
+		err = newAPIError(response)
+	}
+
+	return
+}
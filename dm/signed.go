@@ -0,0 +1,374 @@
+package dm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// SignedS3PartSize is the size of the parts used by UploadObjectSignedS3, only the last part can be smaller
+	SignedS3PartSize = 5 * 1024 * 1024
+	// maxSignedS3Parts is the maximal number of part URLs generated in one request
+	maxSignedS3Parts = 25
+)
+
+// CreateSignedURL creates a URL allowing to read and/or write the object without a token, g.e. from a frontend.
+// If minutesExpiration is 0, the URL is valid for the default of 60 minutes.
+// If singleUse is true, the URL is invalidated after first use.
+func (api BucketAPI) CreateSignedURL(bucketKey, objectName string, access SignedAccess, minutesExpiration int, singleUse bool) (result SignedURL, err error) {
+	return api.CreateSignedURLContext(context.Background(), bucketKey, objectName, access, minutesExpiration, singleUse)
+}
+
+// CreateSignedURLContext is the same as CreateSignedURL, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) CreateSignedURLContext(ctx context.Context, bucketKey, objectName string, access SignedAccess, minutesExpiration int, singleUse bool) (result SignedURL, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	return createSignedURL(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, access, minutesExpiration, singleUse, bearer.AccessToken)
+}
+
+// GetSignedS3UploadURLs returns the URLs allowing to upload the object parts directly to S3.
+// 	Once all parts are uploaded, the upload must be finalized using CompleteSignedS3Upload.
+func (api BucketAPI) GetSignedS3UploadURLs(bucketKey, objectName string, options SignedS3UploadOptions) (result SignedS3Upload, err error) {
+	return api.GetSignedS3UploadURLsContext(context.Background(), bucketKey, objectName, options)
+}
+
+// GetSignedS3UploadURLsContext is the same as GetSignedS3UploadURLs, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) GetSignedS3UploadURLsContext(ctx context.Context, bucketKey, objectName string, options SignedS3UploadOptions) (result SignedS3Upload, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	return getSignedS3Upload(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, options, bearer.AccessToken)
+}
+
+// CompleteSignedS3Upload finalizes a direct-to-S3 upload, given the upload key, and returns details on the uploaded object.
+func (api BucketAPI) CompleteSignedS3Upload(bucketKey, objectName, uploadKey string) (result ObjectDetails, err error) {
+	return api.CompleteSignedS3UploadContext(context.Background(), bucketKey, objectName, uploadKey)
+}
+
+// CompleteSignedS3UploadContext is the same as CompleteSignedS3Upload, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) CompleteSignedS3UploadContext(ctx context.Context, bucketKey, objectName, uploadKey string) (result ObjectDetails, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	return completeSignedS3Upload(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, uploadKey, bearer.AccessToken)
+}
+
+// GetSignedS3DownloadURL returns a URL allowing to download the object directly from S3.
+// If minutesExpiration is 0, the URL is valid for the default of 2 minutes.
+func (api BucketAPI) GetSignedS3DownloadURL(bucketKey, objectName string, minutesExpiration int) (result SignedS3Download, err error) {
+	return api.GetSignedS3DownloadURLContext(context.Background(), bucketKey, objectName, minutesExpiration)
+}
+
+// GetSignedS3DownloadURLContext is the same as GetSignedS3DownloadURL, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) GetSignedS3DownloadURLContext(ctx context.Context, bucketKey, objectName string, minutesExpiration int) (result SignedS3Download, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
+	if err != nil {
+		return
+	}
+	path := api.Authenticator.GetHostPath() + api.BucketAPIPath
+
+	return getSignedS3Download(ctx, oauth.ClientOrDefault(api.Client), path, bucketKey, objectName, minutesExpiration, bearer.AccessToken)
+}
+
+// UploadObjectSignedS3 uploads the data directly to S3, in parts of SignedS3PartSize, and finalizes the upload.
+// Return details on uploaded object, as UploadObject does.
+func (api BucketAPI) UploadObjectSignedS3(bucketKey, objectName string, data io.ReaderAt, size int64) (result ObjectDetails, err error) {
+	return api.UploadObjectSignedS3Context(context.Background(), bucketKey, objectName, data, size)
+}
+
+// UploadObjectSignedS3Context is the same as UploadObjectSignedS3, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) UploadObjectSignedS3Context(ctx context.Context, bucketKey, objectName string, data io.ReaderAt, size int64) (result ObjectDetails, err error) {
+	parts := int((size + SignedS3PartSize - 1) / SignedS3PartSize)
+	if parts == 0 {
+		parts = 1
+	}
+
+	client := oauth.ClientOrDefault(api.Client)
+	uploadKey := ""
+
+	// the URLs are requested in batches, as their number per request is limited
+	for firstPart := 1; firstPart <= parts; firstPart += maxSignedS3Parts {
+		batch := parts - firstPart + 1
+		if batch > maxSignedS3Parts {
+			batch = maxSignedS3Parts
+		}
+
+		var upload SignedS3Upload
+		upload, err = api.GetSignedS3UploadURLsContext(ctx, bucketKey, objectName, SignedS3UploadOptions{
+			Parts:     batch,
+			FirstPart: firstPart,
+			UploadKey: uploadKey,
+		})
+		if err != nil {
+			return
+		}
+		if len(upload.URLs) != batch {
+			err = errors.New("expected " + strconv.Itoa(batch) + " upload URLs, received " + strconv.Itoa(len(upload.URLs)))
+			return
+		}
+		uploadKey = upload.UploadKey
+
+		for idx, partURL := range upload.URLs {
+			start := int64(firstPart-1+idx) * SignedS3PartSize
+			end := start + SignedS3PartSize
+			if end > size {
+				end = size
+			}
+
+			if err = uploadSignedS3Part(ctx, client, partURL, data, start, end-start); err != nil {
+				return
+			}
+		}
+	}
+
+	return api.CompleteSignedS3UploadContext(ctx, bucketKey, objectName, uploadKey)
+}
+
+// DownloadObjectSignedS3 downloads the object directly from S3, copying its content into given writer.
+// Returns the number of written bytes.
+func (api BucketAPI) DownloadObjectSignedS3(bucketKey, objectName string, writer io.Writer) (written int64, err error) {
+	return api.DownloadObjectSignedS3Context(context.Background(), bucketKey, objectName, writer)
+}
+
+// DownloadObjectSignedS3Context is the same as DownloadObjectSignedS3, but takes a context allowing to cancel the request or to set its deadline.
+func (api BucketAPI) DownloadObjectSignedS3Context(ctx context.Context, bucketKey, objectName string, writer io.Writer) (written int64, err error) {
+	download, err := api.GetSignedS3DownloadURLContext(ctx, bucketKey, objectName, 0)
+	if err != nil {
+		return
+	}
+	if download.Status != "complete" {
+		err = errors.New("object " + objectName + " is not available for download, its status is " + download.Status)
+		return
+	}
+
+	return downloadSignedS3(ctx, oauth.ClientOrDefault(api.Client), download.URL, writer)
+}
+
+/*
+ *	SUPPORT FUNCTIONS
+ */
+
+func createSignedURL(ctx context.Context, client oauth.Doer, path, bucketKey, objectName string, access SignedAccess, minutesExpiration int, singleUse bool, token string) (result SignedURL, err error) {
+
+	body := struct {
+		MinutesExpiration int  `json:"minutesExpiration,omitempty"`
+		SingleUse         bool `json:"singleUse"`
+	}{minutesExpiration, singleUse}
+
+	byteParts, err := json.Marshal(body)
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest("POST",
+		path+"/"+bucketKey+"/objects/"+objectName+"/signed",
+		bytes.NewReader(byteParts))
+
+	if err != nil {
+		return
+	}
+
+	if len(access) != 0 {
+		params := req.URL.Query()
+		params.Add("access", string(access))
+		req.URL.RawQuery = params.Encode()
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&result)
+
+	return
+}
+
+func getSignedS3Upload(ctx context.Context, client oauth.Doer, path, bucketKey, objectName string, options SignedS3UploadOptions, token string) (result SignedS3Upload, err error) {
+
+	req, err := http.NewRequest("GET",
+		path+"/"+bucketKey+"/objects/"+objectName+"/signeds3upload",
+		nil)
+
+	if err != nil {
+		return
+	}
+
+	params := req.URL.Query()
+	if options.Parts > 0 {
+		params.Add("parts", strconv.Itoa(options.Parts))
+	}
+	if options.FirstPart > 0 {
+		params.Add("firstPart", strconv.Itoa(options.FirstPart))
+	}
+	if len(options.UploadKey) != 0 {
+		params.Add("uploadKey", options.UploadKey)
+	}
+	if options.MinutesExpiration > 0 {
+		params.Add("minutesExpiration", strconv.Itoa(options.MinutesExpiration))
+	}
+	req.URL.RawQuery = params.Encode()
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&result)
+
+	return
+}
+
+func completeSignedS3Upload(ctx context.Context, client oauth.Doer, path, bucketKey, objectName, uploadKey, token string) (result ObjectDetails, err error) {
+
+	byteParts, err := json.Marshal(struct {
+		UploadKey string `json:"uploadKey"`
+	}{uploadKey})
+	if err != nil {
+		return
+	}
+
+	req, err := http.NewRequest("POST",
+		path+"/"+bucketKey+"/objects/"+objectName+"/signeds3upload",
+		bytes.NewReader(byteParts))
+
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&result)
+
+	return
+}
+
+func getSignedS3Download(ctx context.Context, client oauth.Doer, path, bucketKey, objectName string, minutesExpiration int, token string) (result SignedS3Download, err error) {
+
+	req, err := http.NewRequest("GET",
+		path+"/"+bucketKey+"/objects/"+objectName+"/signeds3download",
+		nil)
+
+	if err != nil {
+		return
+	}
+
+	if minutesExpiration > 0 {
+		params := req.URL.Query()
+		params.Add("minutesExpiration", strconv.Itoa(minutesExpiration))
+		req.URL.RawQuery = params.Encode()
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+		return
+	}
+
+	decoder := json.NewDecoder(response.Body)
+	err = decoder.Decode(&result)
+
+	return
+}
+
+// uploadSignedS3Part sends a part of data to its signed URL, the URL carrying the authorization
+func uploadSignedS3Part(ctx context.Context, client oauth.Doer, partURL string, data io.ReaderAt, start, size int64) (err error) {
+
+	req, err := http.NewRequest("PUT", partURL, io.NewSectionReader(data, start, size))
+	if err != nil {
+		return
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	// allows replaying the part when the request is retried
+	req.GetBody = func() (io.ReadCloser, error) {
+		return ioutil.NopCloser(io.NewSectionReader(data, start, size)), nil
+	}
+
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+	}
+
+	return
+}
+
+func downloadSignedS3(ctx context.Context, client oauth.Doer, downloadURL string, writer io.Writer) (written int64, err error) {
+
+	req, err := http.NewRequest("GET", downloadURL, nil)
+	if err != nil {
+		return
+	}
+
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		err = newAPIError(response)
+		return
+	}
+
+	return io.Copy(writer, response.Body)
+}
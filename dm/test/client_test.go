@@ -0,0 +1,76 @@
+package dm_test
+
+import (
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// countingClient is forwarding the requests to the wrapped client and counts them
+type countingClient struct {
+	sync.Mutex
+	client   oauth.Doer
+	requests []string
+}
+
+func (c *countingClient) Do(req *http.Request) (*http.Response, error) {
+	c.Lock()
+	c.requests = append(c.requests, req.Method+" "+req.URL.Path)
+	c.Unlock()
+	return c.client.Do(req)
+}
+
+func TestBucketAPI_CustomClient(t *testing.T) {
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oss/v2/buckets/test_bucket/details":
+			if r.Header.Get("Authorization") != "Bearer fake_token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"bucketKey":"test_bucket","bucketOwner":"owner","createDate":1,"policyKey":"transient"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &countingClient{client: server.Client()}
+
+	authenticator := newTestAuthenticator(server)
+	authenticator.Client = client
+
+	bucketAPI := dm.NewBucketAPI(authenticator)
+	bucketAPI.Client = client
+
+	t.Run("Use the provided client for token and bucket requests", func(t *testing.T) {
+		details, err := bucketAPI.GetBucketDetails("test_bucket")
+		if err != nil {
+			t.Fatalf("Failed to get bucket details: %s\n", err.Error())
+		}
+
+		if details.BucketKey != "test_bucket" {
+			t.Errorf("Wrong bucket details: %#v", details)
+		}
+
+		if len(client.requests) != 2 {
+			t.Fatalf("Expected 2 requests through the provided client, got %v", client.requests)
+		}
+	})
+
+	t.Run("Fall back to default client when none provided", func(t *testing.T) {
+		bucketAPI.Client = nil
+
+		_, err := bucketAPI.GetBucketDetails("test_bucket")
+		if err != nil {
+			t.Fatalf("Failed to get bucket details: %s\n", err.Error())
+		}
+
+		if len(client.requests) != 3 {
+			t.Fatalf("Expected only the token request through the provided client, got %v", client.requests)
+		}
+	})
+}
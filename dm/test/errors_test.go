@@ -0,0 +1,60 @@
+package dm_test
+
+import (
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"testing"
+)
+
+func TestBucketAPI_APIError(t *testing.T) {
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oss/v2/buckets":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"reason":"Bucket already exists"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"reason":"Bucket not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	bucketAPI := newTestBucketAPI(server)
+
+	t.Run("Get details of non-existing bucket", func(t *testing.T) {
+		_, err := bucketAPI.GetBucketDetails("missing_bucket")
+
+		if !oauth.IsNotFound(err) {
+			t.Fatalf("Expected a not found error, got %v", err)
+		}
+
+		var apiErr *oauth.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Expected an APIError, got %T", err)
+		}
+
+		if apiErr.Method != "GET" || apiErr.Endpoint != server.URL+"/oss/v2/buckets/missing_bucket/details" {
+			t.Errorf("Wrong request details: %s %s", apiErr.Method, apiErr.Endpoint)
+		}
+
+		details, ok := apiErr.Details.(dm.ErrorResult)
+		if !ok || details.Reason != "Bucket not found" {
+			t.Errorf("Expected ErrorResult details, got %#v", apiErr.Details)
+		}
+	})
+
+	t.Run("Create an already existing bucket", func(t *testing.T) {
+		_, err := bucketAPI.CreateBucket("existing_bucket", "transient")
+
+		if !oauth.IsConflict(err) {
+			t.Fatalf("Expected a conflict error, got %v", err)
+		}
+
+		if err.Error() != `[409] {"reason":"Bucket already exists"}` {
+			t.Errorf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
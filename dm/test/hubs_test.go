@@ -0,0 +1,160 @@
+package dm_test
+
+import (
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHubAPI(t *testing.T) {
+
+	var server *httptest.Server
+	server = newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fake_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.EscapedPath() {
+		case "/project/v1/hubs":
+			if r.URL.Query().Get("page[number]") == "1" {
+				w.Write([]byte(`{"jsonapi":{"version":"1.0"},"links":{"self":{"href":""}},
+					"data":[{"type":"hubs","id":"b.hub2","attributes":{"name":"Second","region":"EMEA"}}]}`))
+				return
+			}
+			w.Write([]byte(`{"jsonapi":{"version":"1.0"},"links":{"next":{"href":"` + server.URL + `/project/v1/hubs?page%5Bnumber%5D=1"}},
+				"data":[{"type":"hubs","id":"b.hub1","attributes":{"name":"First","region":"US"}}]}`))
+		case "/project/v1/hubs/b.hub1/projects/b.project/topFolders":
+			w.Write([]byte(`{"data":[{"type":"folders","id":"urn:root","attributes":{"name":"Project Files","displayName":"Project Files"}}]}`))
+		case "/data/v1/projects/b.project/folders/urn:root/contents":
+			w.Write([]byte(`{"data":[
+				{"type":"folders","id":"urn:sub","attributes":{"displayName":"Models"}},
+				{"type":"folders","id":"urn:skipped","attributes":{"displayName":"Archive"}},
+				{"type":"items","id":"urn:item1","attributes":{"displayName":"root.rvt"}}]}`))
+		case "/data/v1/projects/b.project/folders/urn:sub/contents":
+			w.Write([]byte(`{"data":[
+				{"type":"items","id":"urn:item2","attributes":{"displayName":"house.rvt"}},
+				{"type":"items","id":"urn:item3","attributes":{"displayName":"garage.rvt"}}]}`))
+		case "/data/v1/projects/b.project/folders/urn:skipped/contents":
+			w.Write([]byte(`{"data":[]}`))
+		case "/data/v1/projects/b.project/items/urn:item2":
+			w.Write([]byte(`{"data":{"type":"items","id":"urn:item2","attributes":{"displayName":"house.rvt"},
+				"relationships":{
+					"tip":{"data":{"type":"versions","id":"urn:item2?version=2"}},
+					"refs":{"data":[{"type":"versions","id":"urn:ref1"}]},
+					"versions":{"links":{"related":{"href":"versions"}}}}},
+				"included":[{"type":"versions","id":"urn:item2?version=2","attributes":{"versionNumber":2,"storageSize":42}}]}`))
+		case "/data/v1/projects/b.project/versions/urn:item2%3Fversion=2":
+			w.Write([]byte(`{"data":{"type":"versions","id":"urn:item2?version=2","attributes":{"versionNumber":2}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"jsonapi":{"version":"1.0"},"errors":[{"status":"404","code":"NOT_FOUND","detail":"Not found"}]}`))
+		}
+	}))
+	defer server.Close()
+
+	hubAPI := newTestHubAPI(server)
+
+	t.Run("List hubs following the pages", func(t *testing.T) {
+		hubs, err := hubAPI.ListHubs()
+		if err != nil {
+			t.Fatalf("Failed to list hubs: %s\n", err.Error())
+		}
+
+		if len(hubs.Data) != 2 || hubs.Data[1].Attributes.Region != "EMEA" {
+			t.Errorf("Expected hubs from both pages, got %#v", hubs.Data)
+		}
+	})
+
+	t.Run("Walk the project folders", func(t *testing.T) {
+		folders, err := hubAPI.GetTopFolders("b.hub1", "b.project")
+		if err != nil {
+			t.Fatalf("Failed to get top folders: %s\n", err.Error())
+		}
+
+		var visited []string
+		err = hubAPI.WalkFolder("b.project", folders.Data[0].ID, func(parentPath string, resource dm.Resource) error {
+			visited = append(visited, parentPath+"/"+resource.Attributes.DisplayName)
+			if resource.Attributes.DisplayName == "Archive" {
+				return dm.SkipFolder
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to walk the folders: %s\n", err.Error())
+		}
+
+		expected := []string{"/Models", "/Models/house.rvt", "/Models/garage.rvt", "/Archive", "/root.rvt"}
+		if len(visited) != len(expected) {
+			t.Fatalf("Expected to visit %v, got %v", expected, visited)
+		}
+		for idx := range expected {
+			if visited[idx] != expected[idx] {
+				t.Errorf("Expected to visit %v, got %v", expected, visited)
+				break
+			}
+		}
+	})
+
+	t.Run("Skip the rest of a folder from an item", func(t *testing.T) {
+		var visited []string
+		err := hubAPI.WalkFolder("b.project", "urn:root", func(parentPath string, resource dm.Resource) error {
+			visited = append(visited, parentPath+"/"+resource.Attributes.DisplayName)
+			if resource.Attributes.DisplayName == "house.rvt" {
+				return dm.SkipFolder
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("Failed to walk the folders: %s\n", err.Error())
+		}
+
+		expected := []string{"/Models", "/Models/house.rvt", "/Archive", "/root.rvt"}
+		if len(visited) != len(expected) {
+			t.Fatalf("Expected to visit %v, got %v", expected, visited)
+		}
+		for idx := range expected {
+			if visited[idx] != expected[idx] {
+				t.Errorf("Expected to visit %v, got %v", expected, visited)
+				break
+			}
+		}
+	})
+
+	t.Run("Get item with its relationships and tip version", func(t *testing.T) {
+		item, err := hubAPI.GetItem("b.project", "urn:item2")
+		if err != nil {
+			t.Fatalf("Failed to get item: %s\n", err.Error())
+		}
+
+		tip, ok := item.Data.Relationships["tip"].Identifier()
+		if !ok || tip.ID != "urn:item2?version=2" {
+			t.Fatalf("Wrong tip relationship: %#v", item.Data.Relationships["tip"])
+		}
+		if refs := item.Data.Relationships["refs"].DataList; len(refs) != 1 {
+			t.Errorf("Expected one ref, got %#v", refs)
+		}
+		if len(item.Included) != 1 || item.Included[0].Attributes.StorageSize != 42 {
+			t.Errorf("Expected the tip version to be included, got %#v", item.Included)
+		}
+
+		version, err := hubAPI.GetVersion("b.project", tip.ID)
+		if err != nil {
+			t.Fatalf("Failed to get version: %s\n", err.Error())
+		}
+		if version.Data.Attributes.VersionNumber != 2 {
+			t.Errorf("Wrong version: %#v", version.Data)
+		}
+	})
+
+	t.Run("Report JSON:API errors", func(t *testing.T) {
+		_, err := hubAPI.GetItem("b.project", "urn:missing")
+
+		apiErr, ok := err.(*oauth.APIError)
+		if !ok || !oauth.IsNotFound(err) || apiErr.Code != "NOT_FOUND" || apiErr.Reason != "Not found" {
+			t.Errorf("Expected a decoded not found error, got %#v", err)
+		}
+	})
+}
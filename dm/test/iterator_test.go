@@ -0,0 +1,84 @@
+package dm_test
+
+import (
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBucketAPI_Iterators(t *testing.T) {
+
+	requests := 0
+	var server *httptest.Server
+	server = newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oss/v2/buckets":
+			requests++
+			switch r.URL.Query().Get("startAt") {
+			case "":
+				w.Write([]byte(`{"items":[{"bucketKey":"first"},{"bucketKey":"second"}],
+					"next":"` + server.URL + `/oss/v2/buckets?startAt=third&limit=2"}`))
+			case "third":
+				w.Write([]byte(`{"items":[{"bucketKey":"third"}]}`))
+			}
+		case "/oss/v2/buckets/test_bucket/objects":
+			if r.URL.Query().Get("startAt") == "" {
+				w.Write([]byte(`{"items":[],"next":"` + server.URL + `/oss/v2/buckets/test_bucket/objects?startAt=a.txt"}`))
+				return
+			}
+			w.Write([]byte(`{"items":[{"objectKey":"a.txt"},{"objectKey":"b.txt"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	bucketAPI := newTestBucketAPI(server)
+
+	t.Run("Iterate over all bucket pages", func(t *testing.T) {
+		var keys []string
+		it := bucketAPI.Buckets("", "2")
+		for it.Next() {
+			keys = append(keys, it.Bucket().BucketKey)
+		}
+		if it.Err() != nil {
+			t.Fatalf("Failed to iterate over buckets: %s\n", it.Err().Error())
+		}
+
+		if len(keys) != 3 || keys[2] != "third" {
+			t.Errorf("Expected buckets from both pages, got %v", keys)
+		}
+	})
+
+	t.Run("Stop iterating early", func(t *testing.T) {
+		requests = 0
+		it := bucketAPI.Buckets("", "2")
+		if !it.Next() || it.Bucket().BucketKey != "first" {
+			t.Fatalf("Expected the first bucket, got %v", it.Bucket())
+		}
+
+		if requests != 1 {
+			t.Errorf("Expected only the first page to be requested, got %d requests", requests)
+		}
+	})
+
+	t.Run("Iterate over objects skipping empty pages", func(t *testing.T) {
+		var keys []string
+		it := bucketAPI.Objects("test_bucket", "", "")
+		for it.Next() {
+			keys = append(keys, it.Object().ObjectKey)
+		}
+
+		if it.Err() != nil || len(keys) != 2 {
+			t.Errorf("Expected 2 objects, got %v with error %v", keys, it.Err())
+		}
+	})
+
+	t.Run("Report the error stopping the iteration", func(t *testing.T) {
+		it := bucketAPI.Objects("missing_bucket", "", "")
+		if it.Next() || !oauth.IsNotFound(it.Err()) {
+			t.Errorf("Expected a not found error, got %v", it.Err())
+		}
+	})
+}
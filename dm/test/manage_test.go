@@ -0,0 +1,131 @@
+package dm_test
+
+import (
+	"fmt"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBucketAPI_ManageObjects(t *testing.T) {
+
+	var mutex sync.Mutex
+	objects := map[string]string{"source/object.txt": "0123456789", "source/whole.txt": "0123456789"}
+
+	details := func(w http.ResponseWriter, key string) {
+		parts := strings.SplitN(key, "/", 2)
+		w.Write([]byte(`{"bucketKey":"` + parts[0] + `","objectKey":"` + parts[1] +
+			`","size":` + strconv.Itoa(len(objects[key])) + `}`))
+	}
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		// /oss/v2/buckets/{bucket}/objects/{object}[/details|/copyto/{target}]
+		segments := strings.Split(strings.TrimPrefix(r.URL.Path, "/oss/v2/buckets/"), "/")
+		key := segments[0] + "/" + segments[2]
+		content, found := objects[key]
+		if !found && r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch {
+		case len(segments) == 4 && segments[3] == "details":
+			details(w, key)
+		case len(segments) == 5 && segments[3] == "copyto":
+			target := segments[0] + "/" + segments[4]
+			objects[target] = content
+			details(w, target)
+		case r.Method == http.MethodDelete:
+			delete(objects, key)
+		case r.Method == http.MethodPut:
+			data, _ := ioutil.ReadAll(r.Body)
+			objects[key] = string(data)
+			details(w, key)
+		case r.Method == http.MethodGet:
+			var start, end int
+			if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end); err == nil && key != "source/whole.txt" {
+				w.WriteHeader(http.StatusPartialContent)
+				w.Write([]byte(content[start : end+1]))
+				return
+			}
+			w.Write([]byte(content))
+		}
+	}))
+	defer server.Close()
+
+	bucketAPI := newTestBucketAPI(server)
+
+	t.Run("Get object details", func(t *testing.T) {
+		details, err := bucketAPI.GetObjectDetails("source", "object.txt")
+		if err != nil {
+			t.Fatalf("Failed to get object details: %s\n", err.Error())
+		}
+		if details.ObjectKey != "object.txt" || details.Size != 10 {
+			t.Errorf("Wrong object details: %#v", details)
+		}
+	})
+
+	t.Run("Download a range", func(t *testing.T) {
+		data, err := bucketAPI.DownloadObjectRange("source", "object.txt", 2, 5)
+		if err != nil {
+			t.Fatalf("Failed to download range: %s\n", err.Error())
+		}
+		if string(data) != "2345" {
+			t.Errorf("Wrong range content: %s", data)
+		}
+
+		if _, err = bucketAPI.DownloadObjectRange("source", "object.txt", 5, 2); err == nil {
+			t.Errorf("Expected an error for invalid range")
+		}
+
+		if _, err = bucketAPI.DownloadObjectRange("source", "whole.txt", 2, 5); err == nil {
+			t.Errorf("Expected an error when the range is ignored")
+		}
+	})
+
+	t.Run("Copy within and across buckets", func(t *testing.T) {
+		if _, err := bucketAPI.CopyObject("source", "object.txt", "source", "copy.txt"); err != nil {
+			t.Fatalf("Failed to copy object: %s\n", err.Error())
+		}
+
+		details, err := bucketAPI.CopyObject("source", "object.txt", "target", "object.txt")
+		if err != nil {
+			t.Fatalf("Failed to copy object to another bucket: %s\n", err.Error())
+		}
+		if details.BucketKey != "target" || objects["target/object.txt"] != "0123456789" {
+			t.Errorf("Wrong copy to another bucket: %#v", details)
+		}
+	})
+
+	t.Run("Reject renaming an object to itself", func(t *testing.T) {
+		if _, err := bucketAPI.RenameObject("source", "object.txt", "object.txt"); err == nil {
+			t.Errorf("Expected an error when renaming an object to itself")
+		}
+		if _, err := bucketAPI.GetObjectDetails("source", "object.txt"); err != nil {
+			t.Errorf("The object should be kept, got %v", err)
+		}
+	})
+
+	t.Run("Rename and delete", func(t *testing.T) {
+		if _, err := bucketAPI.RenameObject("source", "copy.txt", "renamed.txt"); err != nil {
+			t.Fatalf("Failed to rename object: %s\n", err.Error())
+		}
+		if _, err := bucketAPI.GetObjectDetails("source", "copy.txt"); !oauth.IsNotFound(err) {
+			t.Errorf("Expected the renamed object to be gone, got %v", err)
+		}
+
+		if err := bucketAPI.DeleteObject("source", "renamed.txt"); err != nil {
+			t.Fatalf("Failed to delete object: %s\n", err.Error())
+		}
+		if err := bucketAPI.DeleteObject("source", "renamed.txt"); !oauth.IsNotFound(err) {
+			t.Errorf("Expected a not found error on deleting missing object, got %v", err)
+		}
+	})
+}
@@ -1,10 +1,12 @@
 package dm_test
 
 import (
+	"context"
 	"github.com/apprentice3d/forge-api-go-client/oauth"
 	"io/ioutil"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/apprentice3d/forge-api-go-client/dm"
 )
@@ -162,6 +164,17 @@ func TestBucketAPI_DownloadObject(t *testing.T) {
 
 	})
 
+	t.Run("Download an object using an expired context", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		_, err := bucketAPI.DownloadObjectContext(ctx, tempBucket, object_name)
+		if err == nil {
+			t.Errorf("Expected to fail downloading %s with an expired context", object_name)
+		}
+	})
+
 	t.Run("Delete the temp bucket", func(t *testing.T) {
 		err := bucketAPI.DeleteBucket(tempBucket)
 		if err != nil {
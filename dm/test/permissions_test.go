@@ -0,0 +1,82 @@
+package dm_test
+
+import (
+	"encoding/json"
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"testing"
+)
+
+func TestBucketAPI_BucketAccess(t *testing.T) {
+
+	permissions := map[string]dm.BucketAccess{}
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Allow  []dm.Permission `json:"allow"`
+			Revoke []dm.Permission `json:"revoke"`
+		}
+
+		switch r.URL.Path {
+		case "/oss/v2/buckets/shared_bucket/grant":
+			json.NewDecoder(r.Body).Decode(&body)
+			for _, permission := range body.Allow {
+				permissions[permission.AuthID] = permission.Access
+			}
+		case "/oss/v2/buckets/shared_bucket/revoke":
+			json.NewDecoder(r.Body).Decode(&body)
+			for _, permission := range body.Revoke {
+				delete(permissions, permission.AuthID)
+			}
+		case "/oss/v2/buckets/shared_bucket/details":
+			details := dm.BucketDetails{BucketKey: "shared_bucket"}
+			for authID, access := range permissions {
+				details.Permissions = append(details.Permissions, dm.Permission{AuthID: authID, Access: access})
+			}
+			json.NewEncoder(w).Encode(details)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	bucketAPI := newTestBucketAPI(server)
+
+	t.Run("Grant access to another application", func(t *testing.T) {
+		if err := bucketAPI.GrantBucketAccess("shared_bucket", "production_client_id", dm.BucketAccessRead); err != nil {
+			t.Fatalf("Failed to grant access: %s\n", err.Error())
+		}
+
+		details, err := bucketAPI.GetBucketDetails("shared_bucket")
+		if err != nil {
+			t.Fatalf("Failed to get bucket details: %s\n", err.Error())
+		}
+		if len(details.Permissions) != 1 || details.Permissions[0].Access != dm.BucketAccessRead {
+			t.Errorf("Expected read access to be granted, got %#v", details.Permissions)
+		}
+	})
+
+	t.Run("Reject invalid access", func(t *testing.T) {
+		if err := bucketAPI.GrantBucketAccess("shared_bucket", "production_client_id", "write"); err == nil {
+			t.Errorf("Should fail granting invalid access")
+		}
+	})
+
+	t.Run("Revoke access", func(t *testing.T) {
+		if err := bucketAPI.RevokeBucketAccess("shared_bucket", "production_client_id"); err != nil {
+			t.Fatalf("Failed to revoke access: %s\n", err.Error())
+		}
+
+		if len(permissions) != 0 {
+			t.Errorf("Expected access to be revoked, got %v", permissions)
+		}
+	})
+
+	t.Run("Report missing bucket", func(t *testing.T) {
+		err := bucketAPI.GrantBucketAccess("missing_bucket", "production_client_id", dm.BucketAccessFull)
+		if !oauth.IsNotFound(err) {
+			t.Errorf("Expected a not found error, got %v", err)
+		}
+	})
+}
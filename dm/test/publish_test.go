@@ -0,0 +1,98 @@
+package dm_test
+
+import (
+	"encoding/json"
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestHubAPI_PublishFile(t *testing.T) {
+
+	uploaded := map[string]string{}
+	items := map[string]int{}
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Data     dm.Resource   `json:"data"`
+			Included []dm.Resource `json:"included"`
+		}
+		if r.Method == http.MethodPost {
+			if r.Header.Get("Content-Type") != "application/vnd.api+json" {
+				w.WriteHeader(http.StatusUnsupportedMediaType)
+				return
+			}
+			json.NewDecoder(r.Body).Decode(&request)
+		}
+
+		switch r.URL.Path {
+		case "/data/v1/projects/b.project/storage":
+			target, _ := request.Data.Relationships["target"].Identifier()
+			if target.ID != "urn:folder" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data":{"type":"objects","id":"urn:adsk.objects:os.object:wip.dm.prod/` +
+				request.Data.Attributes.Name + `"}}`))
+		case "/oss/v2/buckets/wip.dm.prod/objects/house.rvt":
+			data, _ := ioutil.ReadAll(r.Body)
+			uploaded["house.rvt"] = string(data)
+			w.Write([]byte(`{"bucketKey":"wip.dm.prod","objectKey":"house.rvt"}`))
+		case "/data/v1/projects/b.project/folders/urn:folder/contents":
+			var data []string
+			for name := range items {
+				data = append(data, `{"type":"items","id":"urn:item","attributes":{"displayName":"`+name+`"}}`)
+			}
+			w.Write([]byte(`{"data":[` + strings.Join(data, ",") + `]}`))
+		case "/data/v1/projects/b.project/items":
+			storage, _ := request.Included[0].Relationships["storage"].Identifier()
+			if request.Data.Attributes.Extension.Type != "items:autodesk.bim360:File" || !strings.HasSuffix(storage.ID, "/house.rvt") {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			items[request.Data.Attributes.DisplayName] = 1
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data":{"type":"items","id":"urn:item"},
+				"included":[{"type":"versions","id":"urn:item?version=1"}]}`))
+		case "/data/v1/projects/b.project/versions":
+			item, _ := request.Data.Relationships["item"].Identifier()
+			if item.ID != "urn:item" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			items[request.Data.Attributes.Name]++
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"data":{"type":"versions","id":"urn:item?version=2"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	hubAPI := newTestHubAPI(server)
+
+	t.Run("Publish a new item", func(t *testing.T) {
+		versionID, err := hubAPI.PublishFile("b.project", "urn:folder", "house.rvt", strings.NewReader("first"), 5)
+		if err != nil {
+			t.Fatalf("Failed to publish file: %s\n", err.Error())
+		}
+
+		if versionID != "urn:item?version=1" || uploaded["house.rvt"] != "first" {
+			t.Errorf("Unexpected first version %s with content %s", versionID, uploaded["house.rvt"])
+		}
+	})
+
+	t.Run("Publish a new version of existing item", func(t *testing.T) {
+		versionID, err := hubAPI.PublishFile("b.project", "urn:folder", "house.rvt", strings.NewReader("second"), 6)
+		if err != nil {
+			t.Fatalf("Failed to publish file: %s\n", err.Error())
+		}
+
+		if versionID != "urn:item?version=2" || items["house.rvt"] != 2 || uploaded["house.rvt"] != "second" {
+			t.Errorf("Unexpected second version %s", versionID)
+		}
+	})
+}
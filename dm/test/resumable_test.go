@@ -0,0 +1,133 @@
+package dm_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// resumableServer imitates the OSS resumable upload, assembling the received chunks per session
+type resumableServer struct {
+	sync.Mutex
+	sessions  map[string][]byte
+	received  map[string]int64
+	failStart int64 // the chunk starting at this offset is rejected once, -1 to accept all
+}
+
+func (s *resumableServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var start, end, total int64
+	if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &total); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	session := r.Header.Get("Session-Id")
+	data, _ := ioutil.ReadAll(r.Body)
+
+	s.Lock()
+	defer s.Unlock()
+
+	if start == s.failStart {
+		s.failStart = -1
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"reason":"Chunk rejected"}`))
+		return
+	}
+
+	if s.sessions[session] == nil {
+		s.sessions[session] = make([]byte, total)
+	}
+	copy(s.sessions[session][start:], data)
+	s.received[session] += int64(len(data))
+
+	if s.received[session] < total {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	w.Write([]byte(`{"bucketKey":"test_bucket","objectKey":"big.bin","size":` + strconv.FormatInt(total, 10) + `}`))
+}
+
+func TestBucketAPI_UploadResumable(t *testing.T) {
+
+	fake := &resumableServer{
+		sessions:  make(map[string][]byte),
+		received:  make(map[string]int64),
+		failStart: -1,
+	}
+	server := newTestServer(fake)
+	defer server.Close()
+
+	bucketAPI := newTestBucketAPI(server)
+
+	content := bytes.Repeat([]byte("0123456789"), dm.MinChunkSize/2)
+
+	t.Run("Upload chunks in parallel", func(t *testing.T) {
+		upload, err := dm.NewResumableUpload("test_bucket", "big.bin", int64(len(content)), dm.MinChunkSize)
+		if err != nil {
+			t.Fatalf("Failed to create upload session: %s\n", err.Error())
+		}
+
+		calls := 0
+		details, err := bucketAPI.UploadResumable(upload, bytes.NewReader(content), dm.ResumableOptions{
+			Parallel:        3,
+			OnChunkUploaded: func(*dm.ResumableUpload, int) { calls++ },
+		})
+		if err != nil {
+			t.Fatalf("Failed to upload: %s\n", err.Error())
+		}
+
+		if details.Size != uint64(len(content)) || calls != len(upload.Uploaded) || !upload.IsComplete() {
+			t.Errorf("Unexpected upload result: %#v after %d chunks", details, calls)
+		}
+		if !bytes.Equal(fake.sessions[upload.SessionID], content) {
+			t.Errorf("The assembled object differs from uploaded content")
+		}
+	})
+
+	t.Run("Resume a failed upload from saved state", func(t *testing.T) {
+		upload, err := dm.NewResumableUpload("test_bucket", "big.bin", int64(len(content)), dm.MinChunkSize)
+		if err != nil {
+			t.Fatalf("Failed to create upload session: %s\n", err.Error())
+		}
+
+		fake.failStart = dm.MinChunkSize
+		var state bytes.Buffer
+		_, err = bucketAPI.UploadResumable(upload, bytes.NewReader(content), dm.ResumableOptions{
+			OnChunkUploaded: func(upload *dm.ResumableUpload, chunk int) {
+				state.Reset()
+				upload.Save(&state)
+			},
+		})
+		if !oauth.IsForbidden(err) {
+			t.Fatalf("Expected the upload to fail with rejected chunk, got %v", err)
+		}
+
+		restored, err := dm.LoadResumableUpload(&state)
+		if err != nil {
+			t.Fatalf("Failed to restore upload session: %s\n", err.Error())
+		}
+		if pending := restored.Pending(); len(pending) != len(restored.Uploaded)-1 || pending[0] != 1 {
+			t.Fatalf("Unexpected pending chunks after failure: %v", pending)
+		}
+
+		details, err := bucketAPI.UploadResumable(restored, bytes.NewReader(content), dm.ResumableOptions{})
+		if err != nil {
+			t.Fatalf("Failed to resume upload: %s\n", err.Error())
+		}
+		if details.Size != uint64(len(content)) || !bytes.Equal(fake.sessions[restored.SessionID], content) {
+			t.Errorf("The resumed upload did not assemble the object")
+		}
+	})
+
+	t.Run("Reject too small chunks", func(t *testing.T) {
+		_, err := dm.NewResumableUpload("test_bucket", "big.bin", int64(len(content)), 1024)
+		if err == nil {
+			t.Errorf("Expected an error for chunks smaller than %d bytes", dm.MinChunkSize)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+package dm_test
+
+import (
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"net/http/httptest"
+)
+
+// newTestServer starts a server answering the authentication requests with a fake token,
+// the other requests being passed to handler
+func newTestServer(handler http.Handler) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/authentication/v1/authenticate" {
+			w.Write([]byte(`{"token_type":"Bearer","expires_in":3599,"access_token":"fake_token"}`))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+}
+
+// newTestAuthenticator returns an authenticator getting its tokens from the test server
+func newTestAuthenticator(server *httptest.Server) *oauth.TwoLeggedAuth {
+	authenticator := oauth.NewTwoLegged("id", "secret")
+	authenticator.Host = server.URL
+	authenticator.Client = server.Client()
+
+	return authenticator
+}
+
+func newTestBucketAPI(server *httptest.Server) dm.BucketAPI {
+	bucketAPI := dm.NewBucketAPI(newTestAuthenticator(server))
+	bucketAPI.Client = server.Client()
+
+	return bucketAPI
+}
+
+func newTestHubAPI(server *httptest.Server) dm.HubAPI {
+	hubAPI := dm.NewHubAPI(newTestAuthenticator(server))
+	hubAPI.Client = server.Client()
+
+	return hubAPI
+}
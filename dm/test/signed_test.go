@@ -0,0 +1,102 @@
+package dm_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestBucketAPI_SignedURLs(t *testing.T) {
+
+	var (
+		mutex  sync.Mutex
+		parts  = map[int][]byte{}
+		object []byte
+		server *httptest.Server
+	)
+
+	server = newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		objectPath := "/oss/v2/buckets/test_bucket/objects/signed.bin"
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/s3/"):
+			// the S3 URLs are signed, thus should not receive the token
+			if len(r.Header.Get("Authorization")) != 0 {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if r.Method == http.MethodGet {
+				w.Write(object)
+				return
+			}
+			part, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/s3/part/"))
+			parts[part], _ = ioutil.ReadAll(r.Body)
+		case r.URL.Path == objectPath+"/signed":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			w.Write([]byte(`{"signedUrl":"` + server.URL + `/s3/signed?access=` + r.URL.Query().Get("access") +
+				`","expiration":1,"singleUse":` + strconv.FormatBool(body["singleUse"].(bool)) + `}`))
+		case r.URL.Path == objectPath+"/signeds3upload" && r.Method == http.MethodGet:
+			count, _ := strconv.Atoi(r.URL.Query().Get("parts"))
+			first, _ := strconv.Atoi(r.URL.Query().Get("firstPart"))
+			result := dm.SignedS3Upload{UploadKey: "upload_key"}
+			for idx := 0; idx < count; idx++ {
+				result.URLs = append(result.URLs, server.URL+"/s3/part/"+strconv.Itoa(first+idx))
+			}
+			json.NewEncoder(w).Encode(result)
+		case r.URL.Path == objectPath+"/signeds3upload" && r.Method == http.MethodPost:
+			object = nil
+			for idx := 1; idx <= len(parts); idx++ {
+				object = append(object, parts[idx]...)
+			}
+			w.Write([]byte(`{"bucketKey":"test_bucket","objectKey":"signed.bin","size":` + strconv.Itoa(len(object)) + `}`))
+		case r.URL.Path == objectPath+"/signeds3download":
+			w.Write([]byte(`{"status":"complete","url":"` + server.URL + `/s3/download","size":` + strconv.Itoa(len(object)) + `}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	bucketAPI := newTestBucketAPI(server)
+
+	t.Run("Create signed URL", func(t *testing.T) {
+		signed, err := bucketAPI.CreateSignedURL("test_bucket", "signed.bin", dm.AccessReadWrite, 10, true)
+		if err != nil {
+			t.Fatalf("Failed to create signed URL: %s\n", err.Error())
+		}
+
+		if !strings.HasSuffix(signed.SignedURL, "access=readwrite") || !signed.SingleUse {
+			t.Errorf("Unexpected signed URL: %#v", signed)
+		}
+	})
+
+	content := bytes.Repeat([]byte("s3"), dm.SignedS3PartSize+1024)
+
+	t.Run("Upload and download directly to S3", func(t *testing.T) {
+		details, err := bucketAPI.UploadObjectSignedS3("test_bucket", "signed.bin", bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			t.Fatalf("Failed to upload through signed URLs: %s\n", err.Error())
+		}
+		if len(parts) != 3 || details.Size != uint64(len(content)) {
+			t.Fatalf("Expected upload in 3 parts, got %d parts and %#v", len(parts), details)
+		}
+
+		var buffer bytes.Buffer
+		if _, err = bucketAPI.DownloadObjectSignedS3("test_bucket", "signed.bin", &buffer); err != nil {
+			t.Fatalf("Failed to download through signed URL: %s\n", err.Error())
+		}
+		if !bytes.Equal(buffer.Bytes(), content) {
+			t.Errorf("Downloaded content differs from uploaded one")
+		}
+	})
+}
@@ -0,0 +1,131 @@
+package dm_test
+
+import (
+	"bytes"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestBucketAPI_Streaming(t *testing.T) {
+
+	stored := map[string][]byte{}
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := filepath.Base(r.URL.Path)
+		switch r.Method {
+		case http.MethodPut:
+			if r.ContentLength < 0 || len(r.TransferEncoding) != 0 {
+				w.WriteHeader(http.StatusLengthRequired)
+				return
+			}
+			data, _ := ioutil.ReadAll(r.Body)
+			stored[name] = data
+			w.Write([]byte(`{"bucketKey":"test_bucket","objectKey":"` + name +
+				`","size":` + strconv.Itoa(len(data)) + `}`))
+		case http.MethodGet:
+			if name == "truncated.bin" {
+				w.Header().Set("Content-Length", "100")
+				w.Write([]byte("forge"))
+				return
+			}
+			data, ok := stored[name]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"reason":"Object not found"}`))
+				return
+			}
+			w.Write(data)
+		}
+	}))
+	defer server.Close()
+
+	bucketAPI := newTestBucketAPI(server)
+
+	content := bytes.Repeat([]byte("forge"), 1024)
+
+	t.Run("Upload from reader and download to writer", func(t *testing.T) {
+		details, err := bucketAPI.UploadObjectFrom("test_bucket", "stream.bin",
+			ioutil.NopCloser(bytes.NewReader(content)), int64(len(content)))
+		if err != nil {
+			t.Fatalf("Failed to upload from reader: %s\n", err.Error())
+		}
+		if details.Size != uint64(len(content)) {
+			t.Errorf("Wrong uploaded size: %d", details.Size)
+		}
+
+		var buffer bytes.Buffer
+		written, err := bucketAPI.DownloadObjectTo("test_bucket", "stream.bin", &buffer)
+		if err != nil {
+			t.Fatalf("Failed to download to writer: %s\n", err.Error())
+		}
+		if written != int64(len(content)) || !bytes.Equal(buffer.Bytes(), content) {
+			t.Errorf("Downloaded content differs from uploaded one")
+		}
+	})
+
+	t.Run("Upload and download files", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "forge-streaming")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer os.RemoveAll(dir)
+
+		source := filepath.Join(dir, "source.bin")
+		if err = ioutil.WriteFile(source, content, 0644); err != nil {
+			t.Fatal(err.Error())
+		}
+
+		if _, err = bucketAPI.UploadFile("test_bucket", "file.bin", source); err != nil {
+			t.Fatalf("Failed to upload file: %s\n", err.Error())
+		}
+
+		target := filepath.Join(dir, "target.bin")
+		if _, err = bucketAPI.DownloadFile("test_bucket", "file.bin", target); err != nil {
+			t.Fatalf("Failed to download file: %s\n", err.Error())
+		}
+
+		downloaded, _ := ioutil.ReadFile(target)
+		if !bytes.Equal(downloaded, content) {
+			t.Errorf("Downloaded file differs from uploaded one")
+		}
+	})
+
+	t.Run("Upload empty object", func(t *testing.T) {
+		details, err := bucketAPI.UploadObjectFrom("test_bucket", "empty.bin",
+			ioutil.NopCloser(bytes.NewReader(nil)), 0)
+		if err != nil {
+			t.Fatalf("Failed to upload empty object: %s\n", err.Error())
+		}
+		if details.Size != 0 {
+			t.Errorf("Wrong uploaded size: %d", details.Size)
+		}
+	})
+
+	t.Run("Remove partially downloaded file", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "forge-streaming")
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer os.RemoveAll(dir)
+
+		target := filepath.Join(dir, "truncated.bin")
+		if _, err = bucketAPI.DownloadFile("test_bucket", "truncated.bin", target); err == nil {
+			t.Fatalf("Expected an error for a truncated download")
+		}
+		if _, err = os.Stat(target); !os.IsNotExist(err) {
+			t.Errorf("The partially downloaded file should be removed")
+		}
+	})
+
+	t.Run("Open missing object", func(t *testing.T) {
+		_, err := bucketAPI.OpenObject("test_bucket", "missing.bin")
+		if !oauth.IsNotFound(err) {
+			t.Errorf("Expected a not found error, got %v", err)
+		}
+	})
+}
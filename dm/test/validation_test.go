@@ -0,0 +1,102 @@
+package dm_test
+
+import (
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestValidateBucketKey(t *testing.T) {
+
+	valid := []string{"abc", "go_testing-bucket.1", strings.Repeat("a", 128)}
+	for _, key := range valid {
+		if err := dm.ValidateBucketKey(key); err != nil {
+			t.Errorf("Bucket key %q should be valid, got %s", key, err.Error())
+		}
+	}
+
+	invalid := map[string]string{
+		"ab":                     "from 3 to 128 characters",
+		strings.Repeat("a", 129): "from 3 to 128 characters",
+		"goTestingBucket":        "character 'T' at position 2",
+		"bucket/key":             "character '/' at position 6",
+	}
+	for key, reason := range invalid {
+		err := dm.ValidateBucketKey(key)
+		if err == nil || !strings.Contains(err.Error(), reason) {
+			t.Errorf("Bucket key %q should be invalid because of %q, got %v", key, reason, err)
+		}
+	}
+}
+
+func TestPolicyAndRegion_Validate(t *testing.T) {
+
+	// the constants are typed, thus have the Validate method
+	policy, region := dm.PolicyTransient, dm.RegionEMEA
+	if err := policy.Validate(); err != nil {
+		t.Errorf("Policy %s should be valid, got %s", policy, err.Error())
+	}
+	if err := region.Validate(); err != nil {
+		t.Errorf("Region %s should be valid, got %s", region, err.Error())
+	}
+
+	if err := dm.Policy("democracy").Validate(); err == nil || !strings.Contains(err.Error(), "transient, temporary or persistent") {
+		t.Errorf("Expected an error listing the policies, got %v", err)
+	}
+}
+
+func TestBucketAPI_CreateBucketValidation(t *testing.T) {
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/oss/v2/buckets":
+			if r.Header.Get("x-ads-region") != "EMEA" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"bucketKey":"european_bucket","policyKey":"persistent"}`))
+		}
+	}))
+	defer server.Close()
+
+	// count the token requests as well
+	client := &countingClient{client: server.Client()}
+	authenticator := newTestAuthenticator(server)
+	authenticator.Client = client
+
+	bucketAPI := dm.NewBucketAPI(authenticator)
+	bucketAPI.Client = client
+
+	t.Run("Reject invalid values without requests", func(t *testing.T) {
+		if _, err := bucketAPI.CreateBucket("goTestingBucket", string(dm.PolicyTransient)); err == nil {
+			t.Errorf("Should fail creating a bucket with invalid name")
+		}
+		if _, err := bucketAPI.CreateBucket("go_testing_bucket", "democracy"); err == nil {
+			t.Errorf("Should fail creating a bucket with invalid policy")
+		}
+		if _, err := bucketAPI.CreateBucketInRegion("go_testing_bucket", dm.PolicyTransient, "MARS"); err == nil {
+			t.Errorf("Should fail creating a bucket in invalid region")
+		}
+
+		region := "MARS"
+		if _, err := bucketAPI.ListBuckets(region, "", ""); err == nil {
+			t.Errorf("Should fail listing the buckets of invalid region")
+		}
+
+		if len(client.requests) != 0 {
+			t.Errorf("Expected no requests for invalid values, got %v", client.requests)
+		}
+	})
+
+	t.Run("Create bucket in region", func(t *testing.T) {
+		details, err := bucketAPI.CreateBucketInRegion("european_bucket", dm.PolicyPersistent, dm.RegionEMEA)
+		if err != nil {
+			t.Fatalf("Failed to create bucket in region: %s\n", err.Error())
+		}
+
+		if details.PolicyKey != string(dm.PolicyPersistent) {
+			t.Errorf("Wrong bucket details: %#v", details)
+		}
+	})
+}
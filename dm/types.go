@@ -10,8 +10,28 @@ import "github.com/apprentice3d/forge-api-go-client/oauth"
 type BucketAPI struct {
 	Authenticator oauth.ForgeAuthenticator
 	BucketAPIPath string
+	Client        oauth.Doer
 }
 
+// Policy specifies the retention period of the objects in a bucket
+type Policy string
+
+// The retention policies available for buckets
+const (
+	PolicyTransient  Policy = "transient"  // objects are kept for 24 hours
+	PolicyTemporary  Policy = "temporary"  // objects are kept for 30 days
+	PolicyPersistent Policy = "persistent" // objects are kept until deleted
+)
+
+// Region specifies the data center where a bucket is stored
+type Region string
+
+// The regions available for buckets, an empty region meaning the default US region
+const (
+	RegionUS   Region = "US"
+	RegionEMEA Region = "EMEA"
+)
+
 // CreateBucketRequest contains the data necessary to be passed upon bucket creation
 type CreateBucketRequest struct {
 	BucketKey string `json:"bucketKey"`
@@ -23,13 +43,25 @@ type BucketDetails struct {
 	BucketKey   string `json:"bucketKey"`
 	BucketOwner string `json:"bucketOwner"`
 	CreateDate  int64 `json:"createDate"`
-	Permissions []struct {
-		AuthID string `json:"authId"`
-		Access string `json:"access"`
-	} `json:"permissions"`
+	Permissions []Permission `json:"permissions"`
 	PolicyKey string `json:"policyKey"`
 }
 
+// BucketAccess specifies the access granted to an application on a bucket
+type BucketAccess string
+
+// The access types that can be granted on a bucket
+const (
+	BucketAccessRead BucketAccess = "read"
+	BucketAccessFull BucketAccess = "full"
+)
+
+// Permission reflects the access of an application, given by its client id, to a bucket
+type Permission struct {
+	AuthID string       `json:"authId"`
+	Access BucketAccess `json:"access,omitempty"`
+}
+
 // ErrorResult reflects the body content when a request failed (g.e. Bad request or key conflict)
 type ErrorResult struct {
 	Reason string `json:"reason"`
@@ -37,12 +69,15 @@ type ErrorResult struct {
 
 // ListedBuckets reflects the response when query Data Management API for buckets associated with current Forge secrets.
 type ListedBuckets struct {
-	Items []struct {
-		BucketKey   string `json:"bucketKey"`
-		CreatedDate int64 `json:"createdDate"`
-		PolicyKey   string `json:"policyKey"`
-	} `json:"items"`
-	Next string `json:"next"`
+	Items []BucketItem `json:"items"`
+	Next  string       `json:"next"`
+}
+
+// BucketItem reflects the details on a bucket, as found in ListedBuckets
+type BucketItem struct {
+	BucketKey   string `json:"bucketKey"`
+	CreatedDate int64  `json:"createdDate"`
+	PolicyKey   string `json:"policyKey"`
 }
 
 
@@ -53,14 +88,174 @@ type ObjectDetails struct {
 	ObjectKey   string            `json:"objectKey"`
 	SHA1        string            `json:"sha1"`
 	Size        uint64            `json:"size"`
-	ContentType string            `json:"contentType, omitempty"`
+	ContentType string            `json:"contentType,omitempty"`
 	Location    string            `json:"location"`
-	BlockSizes  []int64           `json:"blockSizes, omitempty"`
-	Deltas      map[string]string `json:"deltas, omitempty"`
+	BlockSizes  []int64           `json:"blockSizes,omitempty"`
+	Deltas      map[string]string `json:"deltas,omitempty"`
 }
 
 // BucketContent reflects the response when query Data Management API for bucket content.
 type BucketContent struct {
 	Items []ObjectDetails `json:"items"`
 	Next  string          `json:"next"`
-}
\ No newline at end of file
+}
+
+// SignedAccess specifies what a signed URL allows to do with the object
+type SignedAccess string
+
+// The access types that can be granted through a signed URL
+const (
+	AccessRead      SignedAccess = "read"
+	AccessWrite     SignedAccess = "write"
+	AccessReadWrite SignedAccess = "readwrite"
+)
+
+// SignedURL reflects the response when creating a signed URL, allowing access to an object without a token.
+type SignedURL struct {
+	SignedURL  string `json:"signedUrl"`
+	Expiration int64  `json:"expiration"`
+	SingleUse  bool   `json:"singleUse"`
+}
+
+// SignedS3UploadOptions specifies which upload URLs should be generated for a direct-to-S3 upload.
+type SignedS3UploadOptions struct {
+	Parts             int    // How many part URLs to generate, defaults to 1
+	FirstPart         int    // The index of the first part, starting with 1
+	UploadKey         string // The key of an upload started previously, empty for a new upload
+	MinutesExpiration int    // For how many minutes the URLs are valid, 0 for the default of 2 minutes
+}
+
+// SignedS3Upload reflects the response when requesting direct-to-S3 upload URLs.
+// 	Each part is uploaded by a PUT request to its URL, then the upload is finalized using CompleteSignedS3Upload.
+type SignedS3Upload struct {
+	UploadKey        string   `json:"uploadKey"`
+	UploadExpiration string   `json:"uploadExpiration"`
+	URLExpiration    string   `json:"urlExpiration"`
+	URLs             []string `json:"urls"`
+}
+
+// SignedS3Download reflects the response when requesting a direct-to-S3 download URL.
+type SignedS3Download struct {
+	Status string            `json:"status"`
+	URL    string            `json:"url"`
+	Params map[string]string `json:"params"`
+	Size   uint64            `json:"size"`
+	SHA1   string            `json:"sha1"`
+}
+
+
+/* HUB API TYPES */
+
+
+// HubAPI holds the necessary data for making calls to Forge Data Management service on hubs, projects, folders,
+// items and versions. These are available to both 2-legged and 3-legged authenticators.
+type HubAPI struct {
+	Authenticator  oauth.ForgeAuthenticator
+	ProjectAPIPath string
+	DataAPIPath    string
+	Client         oauth.Doer
+}
+
+// JSONAPI reflects the version of JSON:API specification used by a response
+type JSONAPI struct {
+	Version string `json:"version,omitempty"`
+}
+
+// Link reflects a JSON:API link
+type Link struct {
+	Href string `json:"href,omitempty"`
+}
+
+// Links reflects the links of a JSON:API document, resource or relationship.
+// 	Next is empty when there is no further page of data.
+type Links struct {
+	Self    Link `json:"self,omitempty"`
+	Related Link `json:"related,omitempty"`
+	Webview Link `json:"webView,omitempty"`
+	First   Link `json:"first,omitempty"`
+	Prev    Link `json:"prev,omitempty"`
+	Next    Link `json:"next,omitempty"`
+}
+
+// ResourceIdentifier reflects the type and id referring to a resource
+type ResourceIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// Relationship reflects the relation of a resource to another one, given either by data or by links.
+// 	The relationships to several resources, like refs, are found in DataList.
+type Relationship struct {
+	Data     *ResourceIdentifier    `json:"data,omitempty"`
+	DataList []ResourceIdentifier   `json:"-"`
+	Links    *Links                 `json:"links,omitempty"`
+	Meta     map[string]interface{} `json:"meta,omitempty"`
+}
+
+// Extension reflects the type specific data of a resource, g.e. "items:autodesk.bim360:File"
+type Extension struct {
+	Type    string                 `json:"type,omitempty"`
+	Version string                 `json:"version,omitempty"`
+	Schema  *Link                  `json:"schema,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// Attributes reflects the attributes of hubs, projects, folders, items and versions.
+// Only the attributes relevant to the resource type are filled.
+type Attributes struct {
+	Name                 string     `json:"name,omitempty"`
+	DisplayName          string     `json:"displayName,omitempty"`
+	Region               string     `json:"region,omitempty"`
+	CreateTime           string     `json:"createTime,omitempty"`
+	CreateUserID         string     `json:"createUserId,omitempty"`
+	CreateUserName       string     `json:"createUserName,omitempty"`
+	LastModifiedTime     string     `json:"lastModifiedTime,omitempty"`
+	LastModifiedUserID   string     `json:"lastModifiedUserId,omitempty"`
+	LastModifiedUserName string     `json:"lastModifiedUserName,omitempty"`
+	VersionNumber        int        `json:"versionNumber,omitempty"`
+	MimeType             string     `json:"mimeType,omitempty"`
+	FileType             string     `json:"fileType,omitempty"`
+	StorageSize          int64      `json:"storageSize,omitempty"`
+	ObjectCount          int        `json:"objectCount,omitempty"`
+	Hidden               bool       `json:"hidden,omitempty"`
+	Reserved             bool       `json:"reserved,omitempty"`
+	Extension            *Extension `json:"extension,omitempty"`
+}
+
+// Resource reflects a JSON:API resource, like a hub, project, folder, item or version
+type Resource struct {
+	Type          string                  `json:"type"`
+	ID            string                  `json:"id,omitempty"`
+	Attributes    Attributes              `json:"attributes"`
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+	Links         *Links                  `json:"links,omitempty"`
+}
+
+// ResourceDocument reflects the response when requesting a single resource, along with the related resources
+// included by the service (g.e. the tip version of an item).
+type ResourceDocument struct {
+	JSONAPI  JSONAPI    `json:"jsonapi"`
+	Links    Links      `json:"links"`
+	Data     Resource   `json:"data"`
+	Included []Resource `json:"included,omitempty"`
+}
+
+// ResourceList reflects the response when requesting a collection of resources
+type ResourceList struct {
+	JSONAPI  JSONAPI    `json:"jsonapi"`
+	Links    Links      `json:"links"`
+	Data     []Resource `json:"data"`
+	Included []Resource `json:"included,omitempty"`
+}
+
+// JSONAPIErrors reflects the body content when a request to hubs, projects, folders, items or versions failed
+type JSONAPIErrors struct {
+	JSONAPI JSONAPI `json:"jsonapi"`
+	Errors  []struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+		Code   string `json:"code"`
+		Title  string `json:"title"`
+		Detail string `json:"detail"`
+	} `json:"errors"`
+}
@@ -0,0 +1,51 @@
+package dm
+
+import (
+	"errors"
+	"strconv"
+)
+
+const (
+	minBucketKeyLength = 3
+	maxBucketKeyLength = 128
+)
+
+// ValidateBucketKey checks locally that the bucket key follows the OSS rules:
+// 	it should have from 3 to 128 characters, being only lower case letters, digits, '-', '_' or '.'
+// Returns a descriptive error otherwise.
+func ValidateBucketKey(bucketKey string) error {
+	if len(bucketKey) < minBucketKeyLength || len(bucketKey) > maxBucketKeyLength {
+		return errors.New("invalid bucket key \"" + bucketKey + "\": should have from " +
+			strconv.Itoa(minBucketKeyLength) + " to " + strconv.Itoa(maxBucketKeyLength) +
+			" characters, got " + strconv.Itoa(len(bucketKey)))
+	}
+
+	for idx, char := range bucketKey {
+		if (char < 'a' || char > 'z') && (char < '0' || char > '9') && char != '-' && char != '_' && char != '.' {
+			return errors.New("invalid bucket key \"" + bucketKey + "\": character " + strconv.QuoteRune(char) +
+				" at position " + strconv.Itoa(idx) + " is not allowed, use only [-_.a-z0-9]")
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that the policy is one of transient, temporary or persistent
+func (p Policy) Validate() error {
+	switch p {
+	case PolicyTransient, PolicyTemporary, PolicyPersistent:
+		return nil
+	}
+	return errors.New("invalid bucket policy \"" + string(p) + "\": should be one of " +
+		string(PolicyTransient) + ", " + string(PolicyTemporary) + " or " + string(PolicyPersistent))
+}
+
+// Validate checks that the region is one of US or EMEA, an empty region meaning the default one
+func (r Region) Validate() error {
+	switch r {
+	case "", RegionUS, RegionEMEA:
+		return nil
+	}
+	return errors.New("invalid region \"" + string(r) + "\": should be one of " +
+		string(RegionUS) + " or " + string(RegionEMEA))
+}
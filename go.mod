@@ -1,3 +1,3 @@
 module github.com/apprentice3d/forge-api-go-client
 
-go 1.12
+go 1.13
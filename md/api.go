@@ -1,14 +1,19 @@
 package md
 
 import (
+	"context"
 	"github.com/apprentice3d/forge-api-go-client/oauth"
-	"encoding/base64"
+	"time"
 )
 
 // API struct holds all paths necessary to access Model Derivative API
 type ModelDerivativeAPI struct {
 	Authenticator oauth.ForgeAuthenticator
 	ModelDerivativePath string
+	Client oauth.Doer
+	// MetadataPollInterval is the delay before repeating a metadata request answered with 202,
+	// DefaultMetadataPollInterval being used when not set
+	MetadataPollInterval time.Duration
 }
 
 // NewMDAPI returns a Model Derivative API client with default configurations
@@ -16,17 +21,26 @@ func NewMDAPI(authenticator oauth.ForgeAuthenticator) ModelDerivativeAPI {
 	return ModelDerivativeAPI{
 		authenticator,
 		"/modelderivative/v2/designdata",
+		oauth.DefaultClient,
+		DefaultMetadataPollInterval,
 	}
 }
 
 // TranslateWithParams triggers translation job with settings specified in given TranslationParams
 func (a ModelDerivativeAPI) TranslateWithParams(params TranslationParams) (result TranslationResult, err error) {
-	bearer, err := a.Authenticator.GetToken("data:write data:read")
+	return a.TranslateWithParamsContext(context.Background(), params)
+}
+
+// TranslateWithParamsContext is the same as TranslateWithParams, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) TranslateWithParamsContext(ctx context.Context, params TranslationParams) (result TranslationResult, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, a.Authenticator, "data:write data:read")
 	if err != nil {
 		return
 	}
 	path := a.Authenticator.GetHostPath() + a.ModelDerivativePath
-	result, err = translate(path, params, bearer.AccessToken)
+	// the service does not find sources given with the standard alphabet or padded
+	params.Input.URN = URN(params.Input.URN).normalize()
+	result, err = translate(ctx, oauth.ClientOrDefault(a.Client), path, params, bearer.AccessToken)
 
 	return
 }
@@ -38,8 +52,8 @@ var TranslationSVFPreset = TranslationParams{
 		Destination: DestSpec{"us"},
 		Formats: []FormatSpec{
 			FormatSpec{
-				"svf",
-				[]string{"2d", "3d"},
+				Type:  FormatSVF,
+				Views: []string{View2D, View3D},
 			},
 		},
 	},
@@ -48,15 +62,49 @@ var TranslationSVFPreset = TranslationParams{
 // TranslateToSVF is a helper function that will use the TranslationSVFPreset for translating into svf a given ObjectID.
 // It will also take care of converting objectID into Base64 (URL Safe) encoded URN.
 func (a ModelDerivativeAPI) TranslateToSVF(objectID string) (result TranslationResult, err error) {
-	bearer, err := a.Authenticator.GetToken("data:write data:read")
+	return a.TranslateToSVFContext(context.Background(), objectID)
+}
+
+// TranslateToSVFContext is the same as TranslateToSVF, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) TranslateToSVFContext(ctx context.Context, objectID string) (result TranslationResult, err error) {
+	return a.translateWithPreset(ctx, objectID, TranslationSVFPreset)
+}
+
+// TranslationSVF2Preset specifies the minimum necessary for translating a generic (single file, uncompressed)
+// model into svf2, the format replacing svf for the Viewer.
+var TranslationSVF2Preset = TranslationParams{
+	Output: OutputSpec{
+		Destination: DestSpec{"us"},
+		Formats: []FormatSpec{
+			FormatSpec{
+				Type:  FormatSVF2,
+				Views: []string{View2D, View3D},
+			},
+		},
+	},
+}
+
+// TranslateToSVF2 is a helper function that will use the TranslationSVF2Preset for translating into svf2 a given ObjectID.
+// It will also take care of converting objectID into Base64 (URL Safe) encoded URN.
+func (a ModelDerivativeAPI) TranslateToSVF2(objectID string) (result TranslationResult, err error) {
+	return a.TranslateToSVF2Context(context.Background(), objectID)
+}
+
+// TranslateToSVF2Context is the same as TranslateToSVF2, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) TranslateToSVF2Context(ctx context.Context, objectID string) (result TranslationResult, err error) {
+	return a.translateWithPreset(ctx, objectID, TranslationSVF2Preset)
+}
+
+func (a ModelDerivativeAPI) translateWithPreset(ctx context.Context, objectID string, preset TranslationParams) (result TranslationResult, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, a.Authenticator, "data:write data:read")
 	if err != nil {
 		return
 	}
 	path := a.Authenticator.GetHostPath() + a.ModelDerivativePath
-	params := TranslationSVFPreset
-	params.Input.URN = base64.RawStdEncoding.EncodeToString([]byte(objectID))
+	params := preset
+	params.Input.URN = EncodeURN(objectID).String()
 
-	result, err = translate(path, params, bearer.AccessToken)
+	result, err = translate(ctx, oauth.ClientOrDefault(a.Client), path, params, bearer.AccessToken)
 
 	return
 }
@@ -64,13 +112,18 @@ func (a ModelDerivativeAPI) TranslateToSVF(objectID string) (result TranslationR
 
 // GetManifest returns information about derivatives that correspond to a specific source file,
 // including derivative URNs and statuses.
-func (a ModelDerivativeAPI) GetManifest(urn string) (result Manifest, err error) {
-	bearer, err := a.Authenticator.GetToken("data:read")
+func (a ModelDerivativeAPI) GetManifest(urn URN) (result Manifest, err error) {
+	return a.GetManifestContext(context.Background(), urn)
+}
+
+// GetManifestContext is the same as GetManifest, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) GetManifestContext(ctx context.Context, urn URN) (result Manifest, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, a.Authenticator, "data:read")
 	if err != nil {
 		return
 	}
 	path := a.Authenticator.GetHostPath() + a.ModelDerivativePath
-	result, err = getManifest(path, urn, bearer.AccessToken)
+	result, err = getManifest(ctx, oauth.ClientOrDefault(a.Client), path, urn.normalize(), bearer.AccessToken)
 
 	return
 }
@@ -78,13 +131,18 @@ func (a ModelDerivativeAPI) GetManifest(urn string) (result Manifest, err error)
 
 // GetDerivative downloads a selected derivative. To download the file, you need to specify the file’s URN,
 // which you retrieve by calling the GET :urn/manifest endpoint.
-func (a ModelDerivativeAPI) GetDerivative(urn, derivativeUrn string) (data []byte, err error) {
-	bearer, err := a.Authenticator.GetToken("data:read")
+func (a ModelDerivativeAPI) GetDerivative(urn URN, derivativeUrn string) (data []byte, err error) {
+	return a.GetDerivativeContext(context.Background(), urn, derivativeUrn)
+}
+
+// GetDerivativeContext is the same as GetDerivative, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) GetDerivativeContext(ctx context.Context, urn URN, derivativeUrn string) (data []byte, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, a.Authenticator, "data:read")
 	if err != nil {
 		return
 	}
 	path := a.Authenticator.GetHostPath() + a.ModelDerivativePath
-	data, err = getDerivative(path, urn, derivativeUrn, bearer.AccessToken)
+	data, err = getDerivative(ctx, oauth.ClientOrDefault(a.Client), path, urn.normalize(), derivativeUrn, bearer.AccessToken)
 
 	return
 }
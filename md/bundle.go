@@ -0,0 +1,241 @@
+package md
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// MimeSVF is the mime type of manifest children holding an SVF viewable
+	MimeSVF = "application/autodesk-svf"
+	// outputSVF is the output type of derivatives holding SVF viewables
+	outputSVF = "svf"
+	// embeddedAssetPrefix precedes the URI of assets stored inside the SVF file itself
+	embeddedAssetPrefix = "embed:/"
+	// lmvManifestName is the name of the file describing the assets, inside the SVF file
+	lmvManifestName = "manifest.json"
+	// derivativeURNPrefix precedes the model URN in the URNs of its derivatives
+	derivativeURNPrefix = "urn:adsk.viewing:fs.file:"
+	// outputSegment starts the path of the translated files, relative to the model URN
+	outputSegment = "/output/"
+)
+
+// DownloadSVFBundle mirrors into dir all the files needed to display the SVF viewables of a translated model offline:
+// the SVF files, the assets they refer to (geometry, materials, textures, property database) and the other
+// resources of the SVF derivative, like thumbnails. Returns the paths of the written files.
+// 	The files are written under dir with their path relative to the model URN, g.e. "output/1/model.svf",
+// 	thus the references between them remain valid.
+func (a ModelDerivativeAPI) DownloadSVFBundle(urn URN, dir string) (files []string, err error) {
+	return a.DownloadSVFBundleContext(context.Background(), urn, dir)
+}
+
+// DownloadSVFBundleContext is the same as DownloadSVFBundle, but takes a context allowing to cancel the requests or to set their deadline.
+func (a ModelDerivativeAPI) DownloadSVFBundleContext(ctx context.Context, urn URN, dir string) (files []string, err error) {
+	manifest, err := a.GetManifestContext(ctx, urn)
+	if err != nil {
+		return
+	}
+
+	if manifest.Status != StatusSuccess {
+		err = errors.New("the translation of " + urn.String() + " is not complete, its status is " + manifest.Status)
+		return
+	}
+
+	base := ""
+	downloaded := make(map[string]bool)
+	download := func(derivativeURN string) (data []byte, err error) {
+		if downloaded[derivativeURN] {
+			return
+		}
+		downloaded[derivativeURN] = true
+
+		if len(base) == 0 {
+			if base, err = derivativeBase(derivativeURN, manifest.URN, urn); err != nil {
+				return
+			}
+		}
+		target, err := bundlePath(dir, base, derivativeURN)
+		if err != nil {
+			return
+		}
+
+		data, err = a.downloadDerivativeTo(ctx, urn, derivativeURN, target)
+		if err == nil {
+			files = append(files, target)
+		}
+		return
+	}
+
+	for _, derivative := range manifest.Derivatives {
+		if derivative.OutputType != outputSVF {
+			continue
+		}
+
+		err = walkChildren(derivative.Children, func(child *Child) error {
+			if len(child.URN) == 0 {
+				return nil
+			}
+			if child.Mime != MimeSVF {
+				_, err := download(child.URN)
+				return err
+			}
+
+			svf, err := download(child.URN)
+			if err != nil || svf == nil {
+				return err
+			}
+
+			assets, err := svfAssets(child.URN, svf)
+			if err != nil {
+				return err
+			}
+			for _, asset := range assets {
+				if _, err = download(asset); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	if len(files) == 0 {
+		err = errors.New("the manifest of " + urn.String() + " has no SVF viewable")
+	}
+
+	return
+}
+
+// downloadDerivativeTo writes a derivative to the target file, returning its content only for SVF files,
+// these being needed to find the assets, the other files being streamed.
+func (a ModelDerivativeAPI) downloadDerivativeTo(ctx context.Context, urn URN, derivativeURN, target string) (data []byte, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, a.Authenticator, "data:read")
+	if err != nil {
+		return
+	}
+	path := a.Authenticator.GetHostPath() + a.ModelDerivativePath
+
+	body, err := openDerivative(ctx, oauth.ClientOrDefault(a.Client), path, urn.normalize(), url.PathEscape(derivativeURN), bearer.AccessToken)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+
+	if err = os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return
+	}
+
+	if strings.HasSuffix(derivativeURN, ".svf") {
+		if data, err = ioutil.ReadAll(body); err != nil {
+			return
+		}
+		err = ioutil.WriteFile(target, data, 0644)
+		return
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return
+	}
+	_, err = io.Copy(file, body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return
+}
+
+// svfAssets reads the manifest.json inside the SVF file and returns the derivative URNs of its external assets,
+// resolved relative to the URN of the SVF file.
+func svfAssets(svfURN string, svf []byte) (assets []string, err error) {
+	archive, err := zip.NewReader(bytes.NewReader(svf), int64(len(svf)))
+	if err != nil {
+		return
+	}
+
+	var manifest LMVManifest
+	found := false
+	for _, file := range archive.File {
+		if file.Name != lmvManifestName {
+			continue
+		}
+
+		reader, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		err = json.NewDecoder(reader).Decode(&manifest)
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		found = true
+		break
+	}
+
+	if !found {
+		err = errors.New("the SVF file " + svfURN + " has no " + lmvManifestName)
+		return
+	}
+
+	base := path.Dir(svfURN)
+	for _, asset := range manifest.Assets {
+		if len(asset.URI) == 0 || strings.HasPrefix(asset.URI, embeddedAssetPrefix) {
+			continue
+		}
+		assets = append(assets, path.Join(base, asset.URI))
+	}
+
+	return
+}
+
+// derivativeBase returns the part of a derivative URN designating the model, g.e. "urn:adsk.viewing:fs.file:dXJu...",
+// the model URN being given by the manifest or by the caller. The URN may be encoded with the standard alphabet,
+// holding '/' characters, thus when it is not known, the base ends before the "output" segment.
+func derivativeBase(derivativeURN string, modelURNs ...URN) (string, error) {
+	for _, modelURN := range modelURNs {
+		if len(modelURN) == 0 {
+			continue
+		}
+		for _, base := range []string{derivativeURNPrefix + modelURN.String(), derivativeURNPrefix + modelURN.normalize()} {
+			if strings.HasPrefix(derivativeURN, base+"/") {
+				return base, nil
+			}
+		}
+	}
+
+	if separator := strings.Index(derivativeURN, outputSegment); separator > 0 {
+		return derivativeURN[:separator], nil
+	}
+
+	return "", errors.New("unexpected derivative urn: " + derivativeURN)
+}
+
+// bundlePath returns the local path of a derivative, given by its path relative to the model base,
+// like "urn:adsk.viewing:fs.file:dXJu.../output/1/model.svf" being written to "output/1/model.svf" under dir.
+// Derivatives resolved outside of the model are rejected.
+func bundlePath(dir, base, derivativeURN string) (string, error) {
+	if !strings.HasPrefix(derivativeURN, base+"/") || len(derivativeURN) == len(base)+1 {
+		return "", errors.New("the derivative " + derivativeURN + " is outside of the model")
+	}
+
+	relative := path.Clean(derivativeURN[len(base)+1:])
+	if relative == ".." || strings.HasPrefix(relative, "../") {
+		return "", errors.New("the derivative " + derivativeURN + " is outside of the model")
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(relative)), nil
+}
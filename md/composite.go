@@ -0,0 +1,150 @@
+package md
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// defaultCompositeName names the uploaded archive when the folder has no name of its own, like a file system root
+const defaultCompositeName = "composite"
+
+// ZipFolder writes into writer a zip archive of all the files found in dir, with their paths relative to dir.
+func ZipFolder(dir string, writer io.Writer) (err error) {
+	archive := zip.NewWriter(writer)
+
+	err = filepath.Walk(dir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+
+		relative, err := filepath.Rel(dir, filePath)
+		if err != nil {
+			return err
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(relative)
+		header.Method = zip.Deflate
+
+		entry, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(entry, file)
+		return err
+	})
+
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+
+	return
+}
+
+// TranslateFolder translates a composite design, like a Revit model with its links or an Inventor assembly,
+// stored in a local folder: the folder is zipped, uploaded into the bucket through bucketAPI as "<folder name>.zip",
+// or "composite.zip" for a root folder, then translated into the given formats, or into svf if none is given. The URN to follow the translation is found in result.
+// 	The rootFileName is the path of the main file relative to the folder, g.e. "model.rvt" or "assembly/main.iam".
+func (a ModelDerivativeAPI) TranslateFolder(bucketAPI dm.BucketAPI, bucketKey, dir, rootFileName string, formats ...Format) (result TranslationResult, err error) {
+	return a.TranslateFolderContext(context.Background(), bucketAPI, bucketKey, dir, rootFileName, formats...)
+}
+
+// TranslateFolderContext is the same as TranslateFolder, but takes a context allowing to cancel the requests or to set their deadline.
+func (a ModelDerivativeAPI) TranslateFolderContext(ctx context.Context, bucketAPI dm.BucketAPI, bucketKey, dir, rootFileName string, formats ...Format) (result TranslationResult, err error) {
+	rootFileName = path.Clean(filepath.ToSlash(rootFileName))
+	info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(rootFileName)))
+	if err != nil {
+		return
+	}
+	if !info.Mode().IsRegular() {
+		err = errors.New("the root file " + rootFileName + " is not a file")
+		return
+	}
+
+	params := TranslationSVFPreset
+	if len(formats) != 0 {
+		if params, err = NewTranslationParams("", formats...); err != nil {
+			return
+		}
+	}
+
+	archive, err := ioutil.TempFile("", "translate-*.zip")
+	if err != nil {
+		return
+	}
+	defer os.Remove(archive.Name())
+	defer archive.Close()
+
+	if err = ZipFolder(dir, archive); err != nil {
+		return
+	}
+
+	size, err := archive.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return
+	}
+	if _, err = archive.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+
+	objectName, err := compositeObjectName(dir)
+	if err != nil {
+		return
+	}
+	object, err := bucketAPI.UploadObjectFromContext(ctx, bucketKey, url.PathEscape(objectName), archive, size)
+	if err != nil {
+		return
+	}
+	if !strings.HasSuffix(object.ObjectID, "/"+objectName) {
+		err = errors.New("the archive " + objectName + " was uploaded as " + object.ObjectID)
+		return
+	}
+
+	compressed := true
+	params.Input.URN = EncodeURN(object.ObjectID).String()
+	params.Input.CompressedURN = &compressed
+	params.Input.RootFileName = &rootFileName
+
+	result, err = a.TranslateWithParamsContext(ctx, params)
+
+	return
+}
+
+/*
+ *	SUPPORT FUNCTIONS
+ */
+
+// compositeObjectName returns the name of the archive uploaded for dir, resolving relative paths like "."
+// to get the actual folder name.
+func compositeObjectName(dir string) (string, error) {
+	absolute, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	name := filepath.Base(absolute)
+	if name == string(filepath.Separator) || name == "." || len(name) == 0 || name == filepath.VolumeName(absolute) {
+		name = defaultCompositeName
+	}
+
+	return name + ".zip", nil
+}
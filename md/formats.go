@@ -0,0 +1,433 @@
+package md
+
+import (
+	"errors"
+)
+
+// The output formats of translation jobs
+const (
+	FormatSVF       = "svf"
+	FormatSVF2      = "svf2"
+	FormatOBJ       = "obj"
+	FormatSTL       = "stl"
+	FormatSTEP      = "step"
+	FormatIFC       = "ifc"
+	FormatIGES      = "iges"
+	FormatThumbnail = "thumbnail"
+)
+
+// The views to be generated for viewable formats
+const (
+	View2D = "2d"
+	View3D = "3d"
+)
+
+// Format builds the specification of an output format, validating its settings beforehand
+type Format interface {
+	FormatSpec() (FormatSpec, error)
+}
+
+// NewTranslationParams returns the parameters of a job translating the source given by its URN, see EncodeURN,
+// into the given formats, the results being stored in the US region.
+func NewTranslationParams(urn URN, formats ...Format) (params TranslationParams, err error) {
+	if len(formats) == 0 {
+		err = errors.New("no output format specified")
+		return
+	}
+
+	params.Input.URN = urn.normalize()
+	params.Output.Destination.Region = "us"
+
+	for _, format := range formats {
+		var spec FormatSpec
+		if spec, err = format.FormatSpec(); err != nil {
+			return
+		}
+		params.Output.Formats = append(params.Output.Formats, spec)
+	}
+
+	return
+}
+
+// SVFFormat builds the specification of an SVF or SVF2 output, the viewable formats of the Viewer
+type SVFFormat struct {
+	Type     string   // FormatSVF or FormatSVF2
+	Views    []string // View2D and/or View3D
+	Advanced SVFAdvanced
+}
+
+// SVFAdvanced holds the advanced settings of SVF and SVF2 outputs, each applying only to some source formats.
+// Unset values let the service use its defaults.
+type SVFAdvanced struct {
+	// IFC: use the IFC loader of Navisworks
+	SwitchLoader bool `json:"switchLoader,omitempty"`
+	// IFC: "legacy", "modern" or "v3"
+	ConversionMethod string `json:"conversionMethod,omitempty"`
+	// IFC: "hide", "show" or "skip"
+	BuildingStoreys string `json:"buildingStoreys,omitempty"`
+	// IFC: "hide", "show" or "skip"
+	Spaces string `json:"spaces,omitempty"`
+	// IFC: "hide", "show" or "skip"
+	OpeningElements string `json:"openingElements,omitempty"`
+	// Revit: generate master views, one for each phase
+	GenerateMasterViews bool `json:"generateMasterViews,omitempty"`
+	// Revit: "auto", "basic" or "autodesk"
+	MaterialMode string `json:"materialMode,omitempty"`
+	// Revit: "legacy" or "pdf", how sheets and 2D views are generated
+	TwoDViews string `json:"2dviews,omitempty"`
+	// Revit: "next" or "previous"
+	ExtractorVersion string `json:"extractorVersion,omitempty"`
+	// Navisworks: extract hidden objects
+	HiddenObjects bool `json:"hiddenObjects,omitempty"`
+	// Navisworks: extract the basic material properties
+	BasicMaterialProperties bool `json:"basicMaterialProperties,omitempty"`
+	// Navisworks: extract the Autodesk material properties
+	AutodeskMaterialProperties bool `json:"autodeskMaterialProperties,omitempty"`
+	// Navisworks: extract the TimeLiner properties
+	TimelinerProperties bool `json:"timelinerProperties,omitempty"`
+}
+
+// SVF returns the specification of an SVF output with given views, both 2D and 3D if none is given
+func SVF(views ...string) SVFFormat {
+	return SVFFormat{Type: FormatSVF, Views: views}
+}
+
+// SVF2 returns the specification of an SVF2 output with given views, both 2D and 3D if none is given
+func SVF2(views ...string) SVFFormat {
+	return SVFFormat{Type: FormatSVF2, Views: views}
+}
+
+// WithAdvanced returns the format with given advanced settings
+func (f SVFFormat) WithAdvanced(advanced SVFAdvanced) SVFFormat {
+	f.Advanced = advanced
+	return f
+}
+
+// Validate checks the type, the views and the advanced settings of the format
+func (f SVFFormat) Validate() error {
+	if f.Type != FormatSVF && f.Type != FormatSVF2 {
+		return errors.New("unexpected viewable format \"" + f.Type + "\", expecting svf or svf2")
+	}
+
+	for _, view := range f.Views {
+		if view != View2D && view != View3D {
+			return errors.New("unexpected view \"" + view + "\", expecting 2d or 3d")
+		}
+	}
+
+	return f.Advanced.Validate()
+}
+
+// FormatSpec returns the specification of the format to be added to TranslationParams.Output
+func (f SVFFormat) FormatSpec() (spec FormatSpec, err error) {
+	if err = f.Validate(); err != nil {
+		return
+	}
+
+	spec.Type = f.Type
+	spec.Views = f.Views
+	if len(spec.Views) == 0 {
+		spec.Views = []string{View2D, View3D}
+	}
+	if f.Advanced != (SVFAdvanced{}) {
+		advanced := f.Advanced
+		spec.Advanced = &advanced
+	}
+
+	return
+}
+
+// Validate checks that the enumerated settings have one of their accepted values
+func (a SVFAdvanced) Validate() error {
+	settings := []struct {
+		name, value string
+		accepted    []string
+	}{
+		{"conversionMethod", a.ConversionMethod, []string{"legacy", "modern", "v3"}},
+		{"buildingStoreys", a.BuildingStoreys, []string{"hide", "show", "skip"}},
+		{"spaces", a.Spaces, []string{"hide", "show", "skip"}},
+		{"openingElements", a.OpeningElements, []string{"hide", "show", "skip"}},
+		{"materialMode", a.MaterialMode, []string{"auto", "basic", "autodesk"}},
+		{"2dviews", a.TwoDViews, []string{"legacy", "pdf"}},
+		{"extractorVersion", a.ExtractorVersion, []string{"next", "previous"}},
+	}
+
+	for _, setting := range settings {
+		if err := validateSetting(setting.name, setting.value, setting.accepted...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OBJFormat builds the specification of an OBJ output
+type OBJFormat struct {
+	Advanced OBJAdvanced
+}
+
+// OBJAdvanced holds the advanced settings of OBJ outputs
+type OBJAdvanced struct {
+	// "single" or "multiple", whether to export one file for the whole model or one per object
+	ExportFileStructure string `json:"exportFileStructure,omitempty"`
+	// The unit of the geometry, g.e. "meter", "millimeter", "foot" or "inch"
+	Unit string `json:"unit,omitempty"`
+	// The GUID of the viewable containing the objects to export, required along with ObjectIDs
+	ModelGUID string `json:"modelGuid,omitempty"`
+	// The ids of the objects to export, all of them if none is given
+	ObjectIDs []int `json:"objectIds,omitempty"`
+}
+
+// OBJ returns the specification of an OBJ output of the whole model
+func OBJ() OBJFormat {
+	return OBJFormat{}
+}
+
+// WithAdvanced returns the format with given advanced settings
+func (f OBJFormat) WithAdvanced(advanced OBJAdvanced) OBJFormat {
+	f.Advanced = advanced
+	return f
+}
+
+// Validate checks the advanced settings of the format
+func (f OBJFormat) Validate() error {
+	a := f.Advanced
+	if err := validateSetting("exportFileStructure", a.ExportFileStructure, "single", "multiple"); err != nil {
+		return err
+	}
+	if err := validateSetting("unit", a.Unit, "meter", "decimeter", "centimeter", "millimeter", "micrometer",
+		"nanometer", "yard", "foot", "inch", "mil", "microinch"); err != nil {
+		return err
+	}
+	if len(a.ObjectIDs) != 0 && len(a.ModelGUID) == 0 {
+		return errors.New("the modelGuid is required when exporting selected objectIds")
+	}
+	return nil
+}
+
+// FormatSpec returns the specification of the format to be added to TranslationParams.Output
+func (f OBJFormat) FormatSpec() (FormatSpec, error) {
+	a := f.Advanced
+	unset := len(a.ExportFileStructure) == 0 && len(a.Unit) == 0 && len(a.ModelGUID) == 0 && len(a.ObjectIDs) == 0
+	return advancedSpec(FormatOBJ, f.Validate(), a, unset)
+}
+
+// STLFormat builds the specification of an STL output
+type STLFormat struct {
+	Advanced STLAdvanced
+}
+
+// STLAdvanced holds the advanced settings of STL outputs
+type STLAdvanced struct {
+	// "binary" or "ascii"
+	Format string `json:"format,omitempty"`
+	// Whether to export the colors, only for binary files, the service exporting them when unset
+	ExportColor *bool `json:"exportColor,omitempty"`
+	// "single" or "multiple", whether to export one file for the whole model or one per object
+	ExportFileStructure string `json:"exportFileStructure,omitempty"`
+}
+
+// STL returns the specification of an STL output
+func STL() STLFormat {
+	return STLFormat{}
+}
+
+// WithAdvanced returns the format with given advanced settings
+func (f STLFormat) WithAdvanced(advanced STLAdvanced) STLFormat {
+	f.Advanced = advanced
+	return f
+}
+
+// Validate checks the advanced settings of the format
+func (f STLFormat) Validate() error {
+	a := f.Advanced
+	if err := validateSetting("format", a.Format, "binary", "ascii"); err != nil {
+		return err
+	}
+	if a.Format == "ascii" && a.ExportColor != nil && *a.ExportColor {
+		return errors.New("the colors can only be exported in binary STL files")
+	}
+	return validateSetting("exportFileStructure", a.ExportFileStructure, "single", "multiple")
+}
+
+// FormatSpec returns the specification of the format to be added to TranslationParams.Output
+func (f STLFormat) FormatSpec() (FormatSpec, error) {
+	return advancedSpec(FormatSTL, f.Validate(), f.Advanced, f.Advanced == STLAdvanced{})
+}
+
+// STEPFormat builds the specification of a STEP output
+type STEPFormat struct {
+	Advanced STEPAdvanced
+}
+
+// STEPAdvanced holds the advanced settings of STEP outputs
+type STEPAdvanced struct {
+	// "203", "214" or "242", the application protocol of the STEP file
+	ApplicationProtocol string `json:"applicationProtocol,omitempty"`
+	// The tolerance of the geometry, the service using 0.001 when unset
+	Tolerance float64 `json:"tolerance,omitempty"`
+}
+
+// STEP returns the specification of a STEP output
+func STEP() STEPFormat {
+	return STEPFormat{}
+}
+
+// WithAdvanced returns the format with given advanced settings
+func (f STEPFormat) WithAdvanced(advanced STEPAdvanced) STEPFormat {
+	f.Advanced = advanced
+	return f
+}
+
+// Validate checks the advanced settings of the format
+func (f STEPFormat) Validate() error {
+	if f.Advanced.Tolerance < 0 {
+		return errors.New("the tolerance cannot be negative")
+	}
+	return validateSetting("applicationProtocol", f.Advanced.ApplicationProtocol, "203", "214", "242")
+}
+
+// FormatSpec returns the specification of the format to be added to TranslationParams.Output
+func (f STEPFormat) FormatSpec() (FormatSpec, error) {
+	return advancedSpec(FormatSTEP, f.Validate(), f.Advanced, f.Advanced == STEPAdvanced{})
+}
+
+// IFCFormat builds the specification of an IFC output, available for Revit sources
+type IFCFormat struct {
+	Advanced IFCAdvanced
+}
+
+// IFCAdvanced holds the advanced settings of IFC outputs
+type IFCAdvanced struct {
+	// The name of an IFC export setting defined in the Revit model
+	ExportSettingName string `json:"exportSettingName,omitempty"`
+}
+
+// IFC returns the specification of an IFC output
+func IFC() IFCFormat {
+	return IFCFormat{}
+}
+
+// WithAdvanced returns the format with given advanced settings
+func (f IFCFormat) WithAdvanced(advanced IFCAdvanced) IFCFormat {
+	f.Advanced = advanced
+	return f
+}
+
+// Validate checks the advanced settings of the format, none of them being constrained
+func (f IFCFormat) Validate() error {
+	return nil
+}
+
+// FormatSpec returns the specification of the format to be added to TranslationParams.Output
+func (f IFCFormat) FormatSpec() (FormatSpec, error) {
+	return advancedSpec(FormatIFC, f.Validate(), f.Advanced, f.Advanced == IFCAdvanced{})
+}
+
+// IGESFormat builds the specification of an IGES output
+type IGESFormat struct {
+	Advanced IGESAdvanced
+}
+
+// IGESAdvanced holds the advanced settings of IGES outputs
+type IGESAdvanced struct {
+	// The tolerance of the geometry, the service using 0.001 when unset
+	Tolerance float64 `json:"tolerance,omitempty"`
+	// "bounded", "trimmed" or "wireframe"
+	SurfaceType string `json:"surfaceType,omitempty"`
+	// "open", "shell", "surface" or "wireframe"
+	SheetType string `json:"sheetType,omitempty"`
+	// "solid", "surface" or "wireframe"
+	SolidType string `json:"solidType,omitempty"`
+}
+
+// IGES returns the specification of an IGES output
+func IGES() IGESFormat {
+	return IGESFormat{}
+}
+
+// WithAdvanced returns the format with given advanced settings
+func (f IGESFormat) WithAdvanced(advanced IGESAdvanced) IGESFormat {
+	f.Advanced = advanced
+	return f
+}
+
+// Validate checks the advanced settings of the format
+func (f IGESFormat) Validate() error {
+	a := f.Advanced
+	if a.Tolerance < 0 {
+		return errors.New("the tolerance cannot be negative")
+	}
+	if err := validateSetting("surfaceType", a.SurfaceType, "bounded", "trimmed", "wireframe"); err != nil {
+		return err
+	}
+	if err := validateSetting("sheetType", a.SheetType, "open", "shell", "surface", "wireframe"); err != nil {
+		return err
+	}
+	return validateSetting("solidType", a.SolidType, "solid", "surface", "wireframe")
+}
+
+// FormatSpec returns the specification of the format to be added to TranslationParams.Output
+func (f IGESFormat) FormatSpec() (FormatSpec, error) {
+	return advancedSpec(FormatIGES, f.Validate(), f.Advanced, f.Advanced == IGESAdvanced{})
+}
+
+// ThumbnailFormat builds the specification of a thumbnail output
+type ThumbnailFormat struct {
+	Advanced ThumbnailAdvanced
+}
+
+// ThumbnailAdvanced holds the advanced settings of thumbnail outputs
+type ThumbnailAdvanced struct {
+	// One of ThumbnailSmall, ThumbnailMedium or ThumbnailLarge, the service choosing when unset
+	Width int `json:"width,omitempty"`
+	// One of ThumbnailSmall, ThumbnailMedium or ThumbnailLarge, the service choosing when unset
+	Height int `json:"height,omitempty"`
+}
+
+// Thumbnails returns the specification of a thumbnail output with given size in pixels, 0 letting the service choose
+func Thumbnails(width, height int) ThumbnailFormat {
+	return ThumbnailFormat{ThumbnailAdvanced{width, height}}
+}
+
+// Validate checks the size of the thumbnails
+func (f ThumbnailFormat) Validate() error {
+	return ValidateThumbnailSize(f.Advanced.Width, f.Advanced.Height)
+}
+
+// FormatSpec returns the specification of the format to be added to TranslationParams.Output
+func (f ThumbnailFormat) FormatSpec() (FormatSpec, error) {
+	return advancedSpec(FormatThumbnail, f.Validate(), f.Advanced, f.Advanced == ThumbnailAdvanced{})
+}
+
+// advancedSpec returns the specification of a format without views, omitting the advanced settings when unset
+func advancedSpec(formatType string, validationErr error, advanced interface{}, unset bool) (spec FormatSpec, err error) {
+	if validationErr != nil {
+		return spec, errors.New(formatType + ": " + validationErr.Error())
+	}
+
+	spec.Type = formatType
+	if !unset {
+		spec.Advanced = advanced
+	}
+
+	return
+}
+
+// validateSetting checks that an optional setting is either unset or one of the accepted values
+func validateSetting(name, value string, accepted ...string) error {
+	if len(value) == 0 {
+		return nil
+	}
+	for _, candidate := range accepted {
+		if value == candidate {
+			return nil
+		}
+	}
+
+	message := "unexpected value \"" + value + "\" for " + name + ", expecting one of"
+	for _, candidate := range accepted {
+		message += " " + candidate
+	}
+	return errors.New(message)
+}
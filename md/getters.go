@@ -1,16 +1,30 @@
 package md
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io"
 	"io/ioutil"
 	"net/http"
-	"strconv"
+	"strings"
 )
 
-func getDerivative(path string, urn, derivativeUrn, token string) (result []byte, err error) {
-	task := http.Client{}
+func getDerivative(ctx context.Context, client oauth.Doer, path string, urn, derivativeUrn, token string) (result []byte, err error) {
+	body, err := openDerivative(ctx, client, path, urn, derivativeUrn, token)
+	if err != nil {
+		return
+	}
+	defer body.Close()
 
+	result, err = ioutil.ReadAll(body)
+
+	return
+}
+
+// openDerivative requests a derivative, returning the response body to be closed by the caller
+func openDerivative(ctx context.Context, client oauth.Doer, path string, urn, derivativeUrn, token string) (body io.ReadCloser, err error) {
 	req, err := http.NewRequest("GET",
 		path+"/"+urn+"/manifest/"+derivativeUrn,
 		nil,
@@ -21,19 +35,18 @@ func getDerivative(path string, urn, derivativeUrn, token string) (result []byte
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
-	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
+		response.Body.Close()
 		return
 	}
 
-	result, err = ioutil.ReadAll(response.Body)
+	body = response.Body
 
 	return
 }
@@ -44,7 +57,7 @@ type Manifest struct {
 	Status       string       `json:"status"`
 	Progress     string       `json:"progress"`
 	Region       string       `json:"region"`
-	URN          string       `json:"urn"`
+	URN          URN          `json:"urn"`
 	Derivatives  []Derivative `json:"derivatives"`
 }
 
@@ -53,19 +66,59 @@ type Derivative struct {
 	HasThumbnail string `json:"hasThumbnail"`
 	Status       string `json:"status"`
 	Progress     string `json:"progress"`
-	Messages []struct {
-		Type    string `json:"type"`
-		//Message string `json:"message"`
-		Code    string `json:"code"`
-	} `json:"messages,omitempty"`
+	Messages     []Message `json:"messages,omitempty"`
 	OutputType string  `json:"outputType"`
 	Children   []Child `json:"children"`
 }
 
-//BUG: When translating a non-Revit model, the
-// Manifest will contain an array of strings as message,
-// while in case of others it is just a string
+// Message reflects a warning or an error reported on a derivative or on its children.
+// 	The service reports the message either as a string or as an array of strings,
+// 	depending on the translated model, thus both are decoded into Message.
+type Message struct {
+	Type    string   `json:"type"`
+	Code    string   `json:"code"`
+	Message []string `json:"message,omitempty"`
+}
+
+// UnmarshalJSON decodes a message, accepting its text given as a string or as an array of strings,
+// any other value being kept as its raw JSON text.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	var content struct {
+		Type    string          `json:"type"`
+		Code    string          `json:"code"`
+		Message json.RawMessage `json:"message"`
+	}
+	if err := json.Unmarshal(data, &content); err != nil {
+		return err
+	}
+
+	*m = Message{Type: content.Type, Code: content.Code}
+
+	text := bytes.TrimSpace(content.Message)
+	if len(text) == 0 || bytes.Equal(text, []byte("null")) {
+		return nil
+	}
 
+	var single string
+	if err := json.Unmarshal(text, &single); err == nil {
+		m.Message = []string{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(text, &list); err == nil {
+		m.Message = list
+		return nil
+	}
+
+	// an unexpected shape, keep its raw text rather than failing the whole manifest
+	m.Message = []string{string(text)}
+	return nil
+}
+
+// Text returns the message text, joining the lines when it was given as an array
+func (m Message) Text() string {
+	return strings.Join(m.Message, "\n")
+}
 
 type Child struct {
 	GUID         string    `json:"guid"`
@@ -82,16 +135,10 @@ type Child struct {
 	Resolution   []float32 `json:"resolution,omitempty"`
 	Children     []Child   `json:"children,omitempty"`
 	Camera 		[]float32	`json:"camera,omitempty"`
-	Messages []struct {
-		Type    string   `json:"type"`
-		Message []string `json:"message"`
-		Code    string   `json:"code"`
-	} `json:"messages,omitempty"`
+	Messages     []Message `json:"messages,omitempty"`
 }
 
-func getManifest(path string, urn, token string) (result Manifest, err error) {
-	task := http.Client{}
-
+func getManifest(ctx context.Context, client oauth.Doer, path string, urn, token string) (result Manifest, err error) {
 	req, err := http.NewRequest("GET",
 		path+"/"+urn+"/manifest",
 		nil,
@@ -102,15 +149,14 @@ func getManifest(path string, urn, token string) (result Manifest, err error) {
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
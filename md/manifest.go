@@ -0,0 +1,89 @@
+package md
+
+import "errors"
+
+// The roles of manifest children commonly looked for
+const (
+	Role2D         = "2d"
+	Role3D         = "3d"
+	RoleGraphics   = "graphics"
+	RoleThumbnail  = "thumbnail"
+	RoleViewable   = "viewable"
+	RoleLeaflet    = "leaflet"
+	RolePropertyDB = "Autodesk.CloudPlatform.PropertyDatabase"
+)
+
+// typeGeometry is the type of children holding a viewable
+const typeGeometry = "geometry"
+
+// SkipChildren can be returned by the function given to Walk to avoid visiting the children of current child
+var SkipChildren = errors.New("skip the children of this child")
+
+// Walk visits depth-first all the children of all derivatives, calling fn for each of them.
+// If fn returns SkipChildren, the children of current child are not visited; any other error stops the walk.
+// 	The children are given by pointer into the manifest, thus should not be kept after modifying it.
+func (m Manifest) Walk(fn func(child *Child) error) error {
+	for idx := range m.Derivatives {
+		if err := walkChildren(m.Derivatives[idx].Children, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func walkChildren(children []Child, fn func(child *Child) error) error {
+	for idx := range children {
+		err := fn(&children[idx])
+		if err == SkipChildren {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if err = walkChildren(children[idx].Children, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Find returns all the children for which match returns true, in the order of Walk
+func (m Manifest) Find(match func(child *Child) bool) (children []*Child) {
+	m.Walk(func(child *Child) error {
+		if match(child) {
+			children = append(children, child)
+		}
+		return nil
+	})
+	return
+}
+
+// FindByRole returns all the children having given role, g.e. RoleThumbnail or RoleGraphics
+func (m Manifest) FindByRole(role string) []*Child {
+	return m.Find(func(child *Child) bool {
+		return child.Role == role
+	})
+}
+
+// FindByMime returns all the children having given mime type, g.e. "application/pdf"
+func (m Manifest) FindByMime(mime string) []*Child {
+	return m.Find(func(child *Child) bool {
+		return child.Mime == mime
+	})
+}
+
+// Viewables returns the geometry children with given view, Role2D or Role3D, g.e. the sheets or the 3D views of a model
+func (m Manifest) Viewables(view string) []*Child {
+	return m.Find(func(child *Child) bool {
+		return child.Type == typeGeometry && child.Role == view
+	})
+}
+
+// DerivativeURNs returns the URNs of all derivative files, to be downloaded with GetDerivative
+func (m Manifest) DerivativeURNs() (urns []string) {
+	for _, child := range m.Find(func(child *Child) bool { return len(child.URN) != 0 }) {
+		urns = append(urns, child.URN)
+	}
+	return
+}
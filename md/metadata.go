@@ -0,0 +1,185 @@
+package md
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"time"
+)
+
+// DefaultMetadataPollInterval is the delay before repeating a metadata request answered with 202, while the service
+// is still extracting the data, when none is set in ModelDerivativeAPI. It is doubled on each retry, up to DefaultMaxPollInterval.
+const DefaultMetadataPollInterval = 2 * time.Second
+
+// maxMetadataAttempts limits how many times a metadata request answered with 202 is repeated
+const maxMetadataAttempts = 10
+
+// ErrMetadataProcessing is returned when the service is still extracting the requested metadata after all retries
+var ErrMetadataProcessing = errors.New("the metadata is still being processed, retry later")
+
+// Metadata reflects the response when listing the viewables of a translated model
+type Metadata struct {
+	Data struct {
+		Type     string     `json:"type"`
+		Metadata []Viewable `json:"metadata"`
+	} `json:"data"`
+}
+
+// Viewable reflects a model view, whose GUID is used to get its object tree and properties
+type Viewable struct {
+	Name         string `json:"name"`
+	Role         string `json:"role"`
+	GUID         string `json:"guid"`
+	IsMasterView bool   `json:"isMasterView,omitempty"`
+}
+
+// ObjectTree reflects the hierarchy of objects in a viewable
+type ObjectTree struct {
+	Data struct {
+		Type    string       `json:"type"`
+		Objects []TreeObject `json:"objects"`
+	} `json:"data"`
+}
+
+// TreeObject reflects a node of the object tree
+type TreeObject struct {
+	ObjectID int          `json:"objectid"`
+	Name     string       `json:"name"`
+	Objects  []TreeObject `json:"objects,omitempty"`
+}
+
+// Properties reflects the properties of all objects in a viewable
+type Properties struct {
+	Data struct {
+		Type       string             `json:"type"`
+		Collection []ObjectProperties `json:"collection"`
+	} `json:"data"`
+}
+
+// ObjectProperties reflects the properties of an object, grouped by category, g.e. "Dimensions" or "Identity Data"
+type ObjectProperties struct {
+	ObjectID   int                    `json:"objectid"`
+	Name       string                 `json:"name"`
+	ExternalID string                 `json:"externalId"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// Property returns the value of a property given its category and name, and whether it was found
+func (p ObjectProperties) Property(category, name string) (value interface{}, ok bool) {
+	group, ok := p.Properties[category].(map[string]interface{})
+	if !ok {
+		return
+	}
+	value, ok = group[name]
+	return
+}
+
+// GetMetadata returns the list of viewables of a translated model, along with their GUIDs.
+func (a ModelDerivativeAPI) GetMetadata(urn URN) (result Metadata, err error) {
+	return a.GetMetadataContext(context.Background(), urn)
+}
+
+// GetMetadataContext is the same as GetMetadata, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) GetMetadataContext(ctx context.Context, urn URN) (result Metadata, err error) {
+	err = a.getMetadata(ctx, "/"+urn.normalize()+"/metadata", &result)
+	return
+}
+
+// GetObjectTree returns the object hierarchy of a viewable, given its GUID.
+// 	While the service is extracting the tree, the request is repeated, see ModelDerivativeAPI.MetadataPollInterval.
+func (a ModelDerivativeAPI) GetObjectTree(urn URN, guid string) (result ObjectTree, err error) {
+	return a.GetObjectTreeContext(context.Background(), urn, guid)
+}
+
+// GetObjectTreeContext is the same as GetObjectTree, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) GetObjectTreeContext(ctx context.Context, urn URN, guid string) (result ObjectTree, err error) {
+	err = a.getMetadata(ctx, "/"+urn.normalize()+"/metadata/"+guid, &result)
+	return
+}
+
+// GetProperties returns the properties of all objects in a viewable, given its GUID.
+// 	While the service is extracting the properties, the request is repeated, see ModelDerivativeAPI.MetadataPollInterval.
+func (a ModelDerivativeAPI) GetProperties(urn URN, guid string) (result Properties, err error) {
+	return a.GetPropertiesContext(context.Background(), urn, guid)
+}
+
+// GetPropertiesContext is the same as GetProperties, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) GetPropertiesContext(ctx context.Context, urn URN, guid string) (result Properties, err error) {
+	err = a.getMetadata(ctx, "/"+urn.normalize()+"/metadata/"+guid+"/properties", &result)
+	return
+}
+
+// getMetadata requests the endpoint, repeating the request while the service answers it is still processing
+func (a ModelDerivativeAPI) getMetadata(ctx context.Context, endpoint string, result interface{}) (err error) {
+	path := a.Authenticator.GetHostPath() + a.ModelDerivativePath
+	delay := a.MetadataPollInterval
+	if delay <= 0 {
+		delay = DefaultMetadataPollInterval
+	}
+
+	for attempt := 1; ; attempt++ {
+		bearer, err := oauth.GetTokenContext(ctx, a.Authenticator, "data:read")
+		if err != nil {
+			return err
+		}
+
+		processed, err := getMetadata(ctx, oauth.ClientOrDefault(a.Client), path+endpoint, bearer.AccessToken, result)
+		if err != nil || processed {
+			return err
+		}
+		if attempt >= maxMetadataAttempts {
+			return ErrMetadataProcessing
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > DefaultMaxPollInterval {
+			delay = DefaultMaxPollInterval
+		}
+	}
+}
+
+/*
+ *	SUPPORT FUNCTIONS
+ */
+
+// getMetadata decodes the response into result, reporting processed as false when the service answered with 202
+func getMetadata(ctx context.Context, client oauth.Doer, path, token string, result interface{}) (processed bool, err error) {
+	req, err := http.NewRequest("GET",
+		path,
+		nil,
+	)
+
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		processed = true
+		decoder := json.NewDecoder(response.Body)
+		err = decoder.Decode(result)
+	case http.StatusAccepted:
+		// the extraction is in progress, the body only reports the request was accepted
+	default:
+		err = oauth.NewAPIError(response)
+	}
+
+	return
+}
@@ -0,0 +1,186 @@
+package md_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const bundleBase = "urn:adsk.viewing:fs.file:dGVzdF91cm4"
+
+func newSVF(t *testing.T, lmvManifest string) []byte {
+	buffer := &bytes.Buffer{}
+	archive := zip.NewWriter(buffer)
+	for name, content := range map[string]string{"manifest.json": lmvManifest, "metadata.json": "{}"} {
+		writer, err := archive.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		writer.Write([]byte(content))
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buffer.Bytes()
+}
+
+func TestAPI_DownloadSVFBundle(t *testing.T) {
+
+	svf := newSVF(t, `{"name":"LMV Manifest","assets":[
+		{"id":"manifest.json","type":"Autodesk.CloudPlatform.PackageManifest","URI":"embed:/manifest.json"},
+		{"id":"0.pf","type":"Autodesk.CloudPlatform.PackFile","URI":"0.pf"},
+		{"id":"texture","type":"Autodesk.CloudPlatform.Image","URI":"textures/wood.jpg"},
+		{"id":"attrs","type":"Autodesk.CloudPlatform.PropertyAttributes","URI":"../../objects_attrs.json.gz"}]}`)
+
+	derivatives := map[string][]byte{
+		bundleBase + "/output/1/model.svf":         svf,
+		bundleBase + "/output/1/0.pf":              []byte("geometry"),
+		bundleBase + "/output/1/textures/wood.jpg": []byte("texture"),
+		bundleBase + "/objects_attrs.json.gz":      []byte("attributes"),
+		bundleBase + "/output/1/model.sdb":         []byte("property database"),
+		bundleBase + "/output/1/thumbnail.png":     []byte("thumbnail"),
+	}
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const manifestPath = "/modelderivative/v2/designdata/test_urn/manifest"
+		switch {
+		case r.URL.Path == manifestPath:
+			w.Write([]byte(`{"type":"manifest","status":"success","progress":"complete","derivatives":[
+				{"outputType":"svf","status":"success","children":[
+					{"guid":"g1","type":"geometry","role":"3d","children":[
+						{"guid":"g2","type":"resource","role":"graphics","mime":"application/autodesk-svf","urn":"` + bundleBase + `/output/1/model.svf"},
+						{"guid":"g3","type":"resource","role":"thumbnail","mime":"image/png","urn":"` + bundleBase + `/output/1/thumbnail.png"}]},
+					{"guid":"g4","type":"resource","role":"Autodesk.CloudPlatform.PropertyDatabase","mime":"application/autodesk-db","urn":"` + bundleBase + `/output/1/model.sdb"}]},
+				{"outputType":"obj","status":"success","children":[
+					{"guid":"g5","type":"resource","role":"obj","urn":"` + bundleBase + `/output/geometry/model.obj"}]}]}`))
+		case strings.HasPrefix(r.URL.Path, manifestPath+"/"):
+			data, ok := derivatives[strings.TrimPrefix(r.URL.Path, manifestPath+"/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	mdAPI := newTestMDAPI(server)
+
+	dir, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files, err := mdAPI.DownloadSVFBundle("test_urn", dir)
+	if err != nil {
+		t.Fatalf("Failed to download the bundle: %s\n", err.Error())
+	}
+
+	if len(files) != len(derivatives) {
+		sort.Strings(files)
+		t.Errorf("Expected %d files, got %v", len(derivatives), files)
+	}
+
+	for urn, expected := range derivatives {
+		local := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(urn, bundleBase+"/")))
+		content, err := ioutil.ReadFile(local)
+		if err != nil {
+			t.Errorf("Missing file %s: %s", local, err.Error())
+			continue
+		}
+		if !bytes.Equal(content, expected) {
+			t.Errorf("Wrong content for %s", local)
+		}
+	}
+}
+
+func TestAPI_DownloadSVFBundle_Outside(t *testing.T) {
+
+	svf := newSVF(t, `{"assets":[{"id":"escape","URI":"../../../../../etc/passwd"}]}`)
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/modelderivative/v2/designdata/test_urn/manifest":
+			w.Write([]byte(`{"type":"manifest","status":"success","derivatives":[{"outputType":"svf","children":[
+				{"guid":"g2","type":"resource","role":"graphics","mime":"application/autodesk-svf","urn":"` + bundleBase + `/output/1/model.svf"}]}]}`))
+		default:
+			w.Write(svf)
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err := newTestMDAPI(server).DownloadSVFBundle("test_urn", dir); err == nil {
+		t.Errorf("Expected an error for an asset outside of the model")
+	}
+}
+
+func TestAPI_DownloadSVFBundle_StandardURN(t *testing.T) {
+
+	// the service may report the model urn encoded with the standard alphabet, holding '/' characters
+	modelURN := base64.StdEncoding.EncodeToString([]byte(testObjectID))
+	base := "urn:adsk.viewing:fs.file:" + modelURN
+
+	svf := newSVF(t, `{"assets":[
+		{"id":"0.pf","URI":"0.pf"},
+		{"id":"attrs","URI":"../../objects_attrs.json.gz"}]}`)
+
+	derivatives := map[string][]byte{
+		base + "/output/1/model.svf":    svf,
+		base + "/output/1/0.pf":         []byte("geometry"),
+		base + "/objects_attrs.json.gz": []byte("attributes"),
+	}
+
+	urn := md.EncodeURN(testObjectID)
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		manifestPath := "/modelderivative/v2/designdata/" + urn.String() + "/manifest"
+		switch {
+		case r.URL.Path == manifestPath:
+			w.Write([]byte(`{"type":"manifest","status":"success","urn":"` + modelURN + `","derivatives":[
+				{"outputType":"svf","status":"success","children":[
+					{"guid":"g2","type":"resource","role":"graphics","mime":"application/autodesk-svf","urn":"` + base + `/output/1/model.svf"}]}]}`))
+		case strings.HasPrefix(r.URL.Path, manifestPath+"/"):
+			data, ok := derivatives[strings.TrimPrefix(r.URL.Path, manifestPath+"/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(data)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "bundle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err = newTestMDAPI(server).DownloadSVFBundle(urn, dir); err != nil {
+		t.Fatalf("Failed to download the bundle: %s\n", err.Error())
+	}
+
+	for _, name := range []string{"output/1/model.svf", "output/1/0.pf", "objects_attrs.json.gz"} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+			t.Errorf("Missing file %s: %s", name, err.Error())
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package md_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"github.com/apprentice3d/forge-api-go-client/dm"
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestAPI_TranslateFolder(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "assembly")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"main.iam":          "assembly",
+		"parts/bolt.ipt":    "bolt",
+		"parts/nut/nut.ipt": "nut",
+	}
+	for name, content := range files {
+		local := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(local), 0755)
+		if err := ioutil.WriteFile(local, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var uploaded, objectNames []string
+	var job md.TranslationParams
+
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "PUT" && strings.HasPrefix(r.URL.Path, "/oss/v2/buckets/bucket/objects/"):
+			data, _ := ioutil.ReadAll(r.Body)
+			archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for _, file := range archive.File {
+				uploaded = append(uploaded, file.Name)
+			}
+			name := strings.TrimPrefix(r.URL.Path, "/oss/v2/buckets/bucket/objects/")
+			objectNames = append(objectNames, name)
+			w.Write([]byte(`{"bucketKey":"bucket","objectId":"urn:adsk.objects:os.object:bucket/` + name + `","objectKey":"` + name + `"}`))
+		case r.URL.Path == "/modelderivative/v2/designdata/job":
+			body, _ := ioutil.ReadAll(r.Body)
+			json.Unmarshal(body, &job)
+			w.Write([]byte(`{"result":"created","urn":"` + job.Input.URN + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	mdAPI := newTestMDAPI(server)
+	bucketAPI := dm.NewBucketAPI(mdAPI.Authenticator)
+	bucketAPI.Client = server.Client()
+
+	t.Run("Zip, upload and translate the folder", func(t *testing.T) {
+		result, err := mdAPI.TranslateFolder(bucketAPI, "bucket", dir, "main.iam", md.SVF2())
+		if err != nil {
+			t.Fatalf("Failed to translate the folder: %s\n", err.Error())
+		}
+
+		sort.Strings(uploaded)
+		if strings.Join(uploaded, ",") != "main.iam,parts/bolt.ipt,parts/nut/nut.ipt" {
+			t.Errorf("Wrong zipped files: %v", uploaded)
+		}
+
+		if job.Input.CompressedURN == nil || !*job.Input.CompressedURN {
+			t.Errorf("The input should be marked as compressed")
+		}
+		if job.Input.RootFileName == nil || *job.Input.RootFileName != "main.iam" {
+			t.Errorf("Wrong root file name: %v", job.Input.RootFileName)
+		}
+		if job.Output.Formats[0].Type != md.FormatSVF2 {
+			t.Errorf("Wrong output format: %s", job.Output.Formats[0].Type)
+		}
+
+		decoded, err := md.DecodeURN(result.URN)
+		if err != nil || decoded != "urn:adsk.objects:os.object:bucket/"+filepath.Base(dir)+".zip" {
+			t.Errorf("Wrong urn: %s", result.URN)
+		}
+	})
+
+	t.Run("Name the object after the current folder", func(t *testing.T) {
+		workDir, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = os.Chdir(dir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(workDir)
+
+		objectNames = nil
+		if _, err = mdAPI.TranslateFolder(bucketAPI, "bucket", ".", "main.iam"); err != nil {
+			t.Fatalf("Failed to translate the current folder: %s\n", err.Error())
+		}
+		if expected := filepath.Base(dir) + ".zip"; len(objectNames) != 1 || objectNames[0] != expected {
+			t.Errorf("Expected the object %s, got %v", expected, objectNames)
+		}
+	})
+
+	t.Run("Escape the folder name in the object path", func(t *testing.T) {
+		parent, err := ioutil.TempDir("", "composite")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(parent)
+
+		named := filepath.Join(parent, "My #1 model")
+		os.MkdirAll(named, 0755)
+		if err = ioutil.WriteFile(filepath.Join(named, "main.iam"), []byte("assembly"), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		objectNames = nil
+		result, err := mdAPI.TranslateFolder(bucketAPI, "bucket", named, "main.iam")
+		if err != nil {
+			t.Fatalf("Failed to translate the folder: %s\n", err.Error())
+		}
+		if len(objectNames) != 1 || objectNames[0] != "My #1 model.zip" {
+			t.Errorf("Expected the object My #1 model.zip, got %v", objectNames)
+		}
+		if decoded, _ := md.DecodeURN(result.URN); decoded != "urn:adsk.objects:os.object:bucket/My #1 model.zip" {
+			t.Errorf("Wrong urn: %s", decoded)
+		}
+	})
+
+	t.Run("Reject missing root file", func(t *testing.T) {
+		if _, err := mdAPI.TranslateFolder(bucketAPI, "bucket", dir, "missing.iam"); err == nil {
+			t.Errorf("Expected an error for a missing root file")
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package md_test
+
+import (
+	"encoding/json"
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestNewTranslationParams_SVF2(t *testing.T) {
+
+	t.Run("Build svf2 output with advanced settings", func(t *testing.T) {
+		format := md.SVF2(md.View3D).WithAdvanced(md.SVFAdvanced{
+			GenerateMasterViews: true,
+			MaterialMode:        "autodesk",
+		})
+
+		params, err := md.NewTranslationParams("dXJu", format)
+		if err != nil {
+			t.Fatalf("Failed to build parameters: %s\n", err.Error())
+		}
+
+		payload, _ := json.Marshal(params.Output)
+		expected := `{"destination":{"region":"us"},"formats":[{"type":"svf2","views":["3d"],` +
+			`"advanced":{"generateMasterViews":true,"materialMode":"autodesk"}}]}`
+		if string(payload) != expected {
+			t.Errorf("Wrong output payload:\n%s\nexpected:\n%s", payload, expected)
+		}
+		if params.Input.URN != "dXJu" {
+			t.Errorf("Wrong input urn: %s", params.Input.URN)
+		}
+	})
+
+	t.Run("Default to 2d and 3d views without advanced settings", func(t *testing.T) {
+		spec, err := md.SVF2().FormatSpec()
+		if err != nil {
+			t.Fatalf("Failed to build format: %s\n", err.Error())
+		}
+
+		payload, _ := json.Marshal(spec)
+		if string(payload) != `{"type":"svf2","views":["2d","3d"]}` {
+			t.Errorf("Wrong format payload: %s", payload)
+		}
+	})
+
+	t.Run("Reject invalid settings", func(t *testing.T) {
+		invalid := []md.Format{
+			md.SVF2("4d"),
+			md.SVFFormat{Type: "svf3"},
+			md.SVF().WithAdvanced(md.SVFAdvanced{TwoDViews: "dwf"}),
+			md.SVF2().WithAdvanced(md.SVFAdvanced{BuildingStoreys: "remove"}),
+		}
+		for _, format := range invalid {
+			if _, err := md.NewTranslationParams("dXJu", format); err == nil {
+				t.Errorf("Expected an error for %#v", format)
+			}
+		}
+
+		if _, err := md.NewTranslationParams("dXJu"); err == nil {
+			t.Errorf("Expected an error when no format is given")
+		}
+	})
+}
+
+func TestAPI_TranslateToSVF2(t *testing.T) {
+
+	var job md.TranslationParams
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/modelderivative/v2/designdata/job":
+			body, _ := ioutil.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &job); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.Write([]byte(`{"result":"created","urn":"` + job.Input.URN + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := newTestMDAPI(server).TranslateToSVF2("urn:adsk.objects:os.object:bucket/model.rvt")
+	if err != nil {
+		t.Fatalf("Failed to translate: %s\n", err.Error())
+	}
+
+	if result.Result != "created" || len(job.Output.Formats) != 1 || job.Output.Formats[0].Type != md.FormatSVF2 {
+		t.Errorf("Wrong translation job: %#v", job)
+	}
+}
+
+func TestFormatBuilders(t *testing.T) {
+
+	noColor := false
+
+	t.Run("Build the output payload of each format", func(t *testing.T) {
+		formats := []md.Format{
+			md.OBJ().WithAdvanced(md.OBJAdvanced{ModelGUID: "view_guid", ObjectIDs: []int{1, 2}, Unit: "meter"}),
+			md.STL().WithAdvanced(md.STLAdvanced{Format: "binary", ExportColor: &noColor, ExportFileStructure: "multiple"}),
+			md.STEP().WithAdvanced(md.STEPAdvanced{ApplicationProtocol: "214", Tolerance: 0.01}),
+			md.IFC().WithAdvanced(md.IFCAdvanced{ExportSettingName: "IFC2x3 Coordination View"}),
+			md.IGES().WithAdvanced(md.IGESAdvanced{SurfaceType: "bounded", SolidType: "solid"}),
+			md.Thumbnails(md.ThumbnailLarge, md.ThumbnailLarge),
+			md.STL(),
+		}
+
+		params, err := md.NewTranslationParams("dXJu", formats...)
+		if err != nil {
+			t.Fatalf("Failed to build parameters: %s\n", err.Error())
+		}
+
+		payload, _ := json.Marshal(params.Output.Formats)
+		expected := `[` +
+			`{"type":"obj","advanced":{"unit":"meter","modelGuid":"view_guid","objectIds":[1,2]}},` +
+			`{"type":"stl","advanced":{"format":"binary","exportColor":false,"exportFileStructure":"multiple"}},` +
+			`{"type":"step","advanced":{"applicationProtocol":"214","tolerance":0.01}},` +
+			`{"type":"ifc","advanced":{"exportSettingName":"IFC2x3 Coordination View"}},` +
+			`{"type":"iges","advanced":{"surfaceType":"bounded","solidType":"solid"}},` +
+			`{"type":"thumbnail","advanced":{"width":400,"height":400}},` +
+			`{"type":"stl"}]`
+		if string(payload) != expected {
+			t.Errorf("Wrong formats payload:\n%s\nexpected:\n%s", payload, expected)
+		}
+	})
+
+	t.Run("Reject invalid advanced settings", func(t *testing.T) {
+		yes := true
+		invalid := []md.Format{
+			md.OBJ().WithAdvanced(md.OBJAdvanced{ObjectIDs: []int{1}}),
+			md.OBJ().WithAdvanced(md.OBJAdvanced{Unit: "parsec"}),
+			md.STL().WithAdvanced(md.STLAdvanced{Format: "ascii", ExportColor: &yes}),
+			md.STL().WithAdvanced(md.STLAdvanced{ExportFileStructure: "several"}),
+			md.STEP().WithAdvanced(md.STEPAdvanced{ApplicationProtocol: "999"}),
+			md.STEP().WithAdvanced(md.STEPAdvanced{Tolerance: -1}),
+			md.IGES().WithAdvanced(md.IGESAdvanced{SheetType: "paper"}),
+			md.Thumbnails(150, 150),
+		}
+		for _, format := range invalid {
+			if _, err := format.FormatSpec(); err == nil {
+				t.Errorf("Expected an error for %#v", format)
+			}
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package md_test
+
+import (
+	"encoding/json"
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"testing"
+)
+
+func TestManifest_MessagesDecoding(t *testing.T) {
+
+	data := []byte(`{"status":"success","derivatives":[{
+		"status":"success","outputType":"svf",
+		"messages":[{"type":"warning","code":"Revit-MissingLink","message":"<message>Missing link</message>"}],
+		"children":[{"guid":"1","type":"geometry","role":"3d",
+			"messages":[{"type":"error","code":"ATF-1024","message":["First line","Second line"]},
+				{"type":"warning","code":"No-Text"}]}]}]}`)
+
+	var manifest md.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("Failed to decode manifest: %s\n", err.Error())
+	}
+
+	t.Run("Decode message given as string", func(t *testing.T) {
+		message := manifest.Derivatives[0].Messages[0]
+		if message.Code != "Revit-MissingLink" || message.Text() != "<message>Missing link</message>" {
+			t.Errorf("Wrong derivative message: %#v", message)
+		}
+	})
+
+	t.Run("Decode message given as array", func(t *testing.T) {
+		messages := manifest.Derivatives[0].Children[0].Messages
+		if len(messages) != 2 || len(messages[0].Message) != 2 || messages[0].Text() != "First line\nSecond line" {
+			t.Errorf("Wrong child messages: %#v", messages)
+		}
+		if messages[1].Code != "No-Text" || len(messages[1].Message) != 0 {
+			t.Errorf("Wrong message without text: %#v", messages[1])
+		}
+	})
+
+	t.Run("Keep message of unexpected shape as raw text", func(t *testing.T) {
+		var message md.Message
+		if err := json.Unmarshal([]byte(`{"type":"error","message":{"text":"Odd"}}`), &message); err != nil {
+			t.Fatalf("Failed to decode message of unexpected shape: %s\n", err.Error())
+		}
+		if message.Type != "error" || message.Text() != `{"text":"Odd"}` {
+			t.Errorf("Wrong message of unexpected shape: %#v", message)
+		}
+
+		var manifest md.Manifest
+		err := json.Unmarshal([]byte(`{"status":"failed","derivatives":[{"status":"failed",
+			"messages":[{"type":"error","code":"Numeric","message":42}]}]}`), &manifest)
+		if err != nil {
+			t.Fatalf("Failed to decode manifest with numeric message: %s\n", err.Error())
+		}
+		if text := manifest.Derivatives[0].Messages[0].Text(); text != "42" {
+			t.Errorf("Wrong numeric message text: %s", text)
+		}
+	})
+}
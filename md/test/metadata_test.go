@@ -0,0 +1,96 @@
+package md_test
+
+import (
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAPI_Metadata(t *testing.T) {
+
+	polls := 0
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		base := "/modelderivative/v2/designdata/test_urn/metadata"
+		switch r.URL.Path {
+		case base:
+			w.Write([]byte(`{"data":{"type":"metadata","metadata":[{"name":"{3D}","role":"3d","guid":"view_guid","isMasterView":true}]}}`))
+		case base + "/view_guid":
+			polls++
+			if polls < 3 {
+				w.WriteHeader(http.StatusAccepted)
+				w.Write([]byte(`{"result":"success"}`))
+				return
+			}
+			w.Write([]byte(`{"data":{"type":"objects","objects":[{"objectid":1,"name":"Model","objects":[{"objectid":2,"name":"Wall"}]}]}}`))
+		case base + "/view_guid/properties":
+			w.Write([]byte(`{"data":{"type":"properties","collection":[{"objectid":2,"name":"Wall","externalId":"ext-2",
+				"properties":{"Dimensions":{"Length":"5000 mm","Area":12.5},"Identity Data":{"Mark":"W1"}}}]}}`))
+		case base + "/processing_guid":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	mdAPI := newTestMDAPI(server)
+	mdAPI.MetadataPollInterval = time.Millisecond
+
+	t.Run("List viewables", func(t *testing.T) {
+		metadata, err := mdAPI.GetMetadata("test_urn")
+		if err != nil {
+			t.Fatalf("Failed to get metadata: %s\n", err.Error())
+		}
+
+		views := metadata.Data.Metadata
+		if len(views) != 1 || views[0].GUID != "view_guid" || !views[0].IsMasterView {
+			t.Errorf("Wrong viewables: %#v", views)
+		}
+	})
+
+	t.Run("Get object tree retrying while processing", func(t *testing.T) {
+		tree, err := mdAPI.GetObjectTree("test_urn", "view_guid")
+		if err != nil {
+			t.Fatalf("Failed to get object tree: %s\n", err.Error())
+		}
+
+		if polls != 3 || tree.Data.Objects[0].Objects[0].Name != "Wall" {
+			t.Errorf("Wrong object tree after %d polls: %#v", polls, tree.Data)
+		}
+	})
+
+	t.Run("Get properties", func(t *testing.T) {
+		properties, err := mdAPI.GetProperties("test_urn", "view_guid")
+		if err != nil {
+			t.Fatalf("Failed to get properties: %s\n", err.Error())
+		}
+
+		wall := properties.Data.Collection[0]
+		if value, ok := wall.Property("Dimensions", "Area"); !ok || value.(float64) != 12.5 {
+			t.Errorf("Wrong area property: %v", value)
+		}
+		if value, ok := wall.Property("Identity Data", "Mark"); !ok || value != "W1" {
+			t.Errorf("Wrong mark property: %v", value)
+		}
+		if _, ok := wall.Property("Missing", "Mark"); ok {
+			t.Errorf("Should not find property in missing category")
+		}
+	})
+
+	t.Run("Give up when still processing", func(t *testing.T) {
+		_, err := mdAPI.GetObjectTree("test_urn", "processing_guid")
+		if !errors.Is(err, md.ErrMetadataProcessing) {
+			t.Errorf("Expected ErrMetadataProcessing, got %v", err)
+		}
+	})
+
+	t.Run("Report missing model", func(t *testing.T) {
+		_, err := mdAPI.GetMetadata("missing_urn")
+		if !oauth.IsNotFound(err) {
+			t.Errorf("Expected a not found error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,31 @@
+package md_test
+
+import (
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"net/http"
+	"net/http/httptest"
+)
+
+// newTestServer starts a server answering the authentication requests with a fake token,
+// the other requests being passed to handler
+func newTestServer(handler http.Handler) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/authentication/v1/authenticate" {
+			w.Write([]byte(`{"token_type":"Bearer","expires_in":3599,"access_token":"fake_token"}`))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+}
+
+func newTestMDAPI(server *httptest.Server) md.ModelDerivativeAPI {
+	authenticator := oauth.NewTwoLegged("id", "secret")
+	authenticator.Host = server.URL
+	authenticator.Client = server.Client()
+
+	mdAPI := md.NewMDAPI(authenticator)
+	mdAPI.Client = server.Client()
+
+	return mdAPI
+}
@@ -0,0 +1,81 @@
+package md_test
+
+import (
+	"bytes"
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// pngHeader is enough of a PNG image for its content type to be detected
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestAPI_GetThumbnail(t *testing.T) {
+
+	var lastQuery string
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/modelderivative/v2/designdata/test_urn/thumbnail":
+			lastQuery = r.URL.RawQuery
+			w.Header().Set("Content-Type", "image/png")
+			w.Write(pngHeader)
+		case "/modelderivative/v2/designdata/untyped_urn/thumbnail":
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write(pngHeader)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	mdAPI := newTestMDAPI(server)
+
+	t.Run("Get thumbnail of given size", func(t *testing.T) {
+		thumbnail, err := mdAPI.GetThumbnail("test_urn", md.ThumbnailLarge, md.ThumbnailLarge)
+		if err != nil {
+			t.Fatalf("Failed to get thumbnail: %s\n", err.Error())
+		}
+
+		if thumbnail.ContentType != "image/png" || !bytes.Equal(thumbnail.Data, pngHeader) {
+			t.Errorf("Wrong thumbnail: %s %v", thumbnail.ContentType, thumbnail.Data)
+		}
+		if lastQuery != "height=400&width=400" {
+			t.Errorf("Wrong query: %s", lastQuery)
+		}
+	})
+
+	t.Run("Let the service choose the size", func(t *testing.T) {
+		if _, err := mdAPI.GetThumbnail("test_urn", 0, 0); err != nil {
+			t.Fatalf("Failed to get thumbnail: %s\n", err.Error())
+		}
+		if lastQuery != "" {
+			t.Errorf("Expected no query, got %s", lastQuery)
+		}
+	})
+
+	t.Run("Detect the content type", func(t *testing.T) {
+		reader, contentType, err := mdAPI.OpenThumbnail("untyped_urn", md.ThumbnailSmall, md.ThumbnailSmall)
+		if err != nil {
+			t.Fatalf("Failed to open thumbnail: %s\n", err.Error())
+		}
+		defer reader.Close()
+
+		data, err := ioutil.ReadAll(reader)
+		if err != nil {
+			t.Fatalf("Failed to read thumbnail: %s\n", err.Error())
+		}
+		if contentType != "image/png" || !bytes.Equal(data, pngHeader) {
+			t.Errorf("Wrong thumbnail: %s %v", contentType, data)
+		}
+	})
+
+	t.Run("Reject unsupported size", func(t *testing.T) {
+		if _, err := mdAPI.GetThumbnail("test_urn", 300, 300); err == nil {
+			t.Errorf("Expected an error for an unsupported size")
+		}
+		if err := md.ValidateThumbnailSize(md.ThumbnailMedium, 0); err != nil {
+			t.Errorf("Unexpected error: %s", err.Error())
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package md_test
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// testObjectID encodes in base64 with both '+' and '/' in the standard alphabet
+const testObjectID = "urn:adsk.objects:os.object:bucket/model~?>.rvt"
+
+func TestEncodeURN(t *testing.T) {
+
+	standard := base64.StdEncoding.EncodeToString([]byte(testObjectID))
+	if !strings.ContainsAny(standard, "+/=") {
+		t.Fatalf("The test object id should need the characters specific to the standard alphabet: %s", standard)
+	}
+
+	urn := md.EncodeURN(testObjectID)
+	if strings.ContainsAny(urn.String(), "+/=") {
+		t.Errorf("The urn should be URL-safe and unpadded: %s", urn)
+	}
+
+	for _, candidate := range []md.URN{urn, md.URN(standard), md.URN(base64.URLEncoding.EncodeToString([]byte(testObjectID)))} {
+		decoded, err := md.DecodeURN(candidate)
+		if err != nil {
+			t.Errorf("Failed to decode %s: %s", candidate, err.Error())
+			continue
+		}
+		if decoded != testObjectID {
+			t.Errorf("Wrong object id decoded from %s: %s", candidate, decoded)
+		}
+	}
+
+	if decoded, err := md.DecodeURN(md.EncodeURN(testObjectID)); err != nil || decoded != testObjectID {
+		t.Errorf("Failed the encoding round trip: %s, %v", decoded, err)
+	}
+
+	if _, err := md.DecodeURN("not*base64"); err == nil {
+		t.Errorf("Expected an error for an invalid urn")
+	}
+}
+
+func TestAPI_GetManifest_URN(t *testing.T) {
+
+	urn := md.EncodeURN(testObjectID)
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/modelderivative/v2/designdata/" + urn.String() + "/manifest":
+			w.Write([]byte(`{"type":"manifest","status":"success","urn":"` + urn.String() + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	mdAPI := newTestMDAPI(server)
+
+	for _, candidate := range []md.URN{urn, md.URN(base64.StdEncoding.EncodeToString([]byte(testObjectID)))} {
+		manifest, err := mdAPI.GetManifest(candidate)
+		if err != nil {
+			t.Errorf("Failed to get the manifest of %s: %s", candidate, err.Error())
+			continue
+		}
+		if manifest.URN != urn {
+			t.Errorf("Wrong manifest urn: %s", manifest.URN)
+		}
+	}
+}
+
+func TestAPI_TranslateWithParams_URN(t *testing.T) {
+
+	urn := md.EncodeURN(testObjectID)
+	var received []string
+	server := newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/modelderivative/v2/designdata/job":
+			var job md.TranslationParams
+			json.NewDecoder(r.Body).Decode(&job)
+			received = append(received, job.Input.URN)
+			w.Write([]byte(`{"result":"created","urn":"` + job.Input.URN + `"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	mdAPI := newTestMDAPI(server)
+	standard := md.URN(base64.StdEncoding.EncodeToString([]byte(testObjectID)))
+
+	params, err := md.NewTranslationParams(standard, md.SVF2())
+	if err != nil {
+		t.Fatalf("Failed to build the translation params: %s\n", err.Error())
+	}
+	if params.Input.URN != urn.String() {
+		t.Errorf("The params should hold the normalized urn, got %s", params.Input.URN)
+	}
+
+	params.Input.URN = standard.String()
+	result, err := mdAPI.TranslateWithParams(params)
+	if err != nil {
+		t.Fatalf("Failed to translate: %s\n", err.Error())
+	}
+	if len(received) != 1 || received[0] != urn.String() || result.URN != urn {
+		t.Errorf("The job should be sent the normalized urn, got %v", received)
+	}
+}
@@ -0,0 +1,90 @@
+package md_test
+
+import (
+	"context"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// newManifestServer serves the given manifests in order, repeating the last one
+func newManifestServer(manifests ...string) *httptest.Server {
+	polls := 0
+	return newTestServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idx := polls
+		if idx >= len(manifests) {
+			idx = len(manifests) - 1
+		}
+		polls++
+		w.Write([]byte(manifests[idx]))
+	}))
+}
+
+func TestAPI_WaitForTranslation(t *testing.T) {
+
+	options := md.WaitOptions{Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond}
+
+	t.Run("Wait until translation succeeds", func(t *testing.T) {
+		server := newManifestServer(
+			`{"status":"pending","progress":"0% complete"}`,
+			`{"status":"inprogress","progress":"45% complete"}`,
+			`{"status":"success","progress":"complete","derivatives":[{"status":"success","outputType":"svf"}]}`,
+		)
+		defer server.Close()
+
+		var percents []int
+		progress := make(chan md.TranslationProgress, 3)
+		waitOptions := options
+		waitOptions.Progress = progress
+		waitOptions.OnProgress = func(progress md.TranslationProgress) {
+			percents = append(percents, progress.Percent)
+		}
+
+		manifest, err := newTestMDAPI(server).WaitForTranslation(context.Background(), "urn", waitOptions)
+		if err != nil {
+			t.Fatalf("Failed to wait for translation: %s\n", err.Error())
+		}
+
+		if manifest.Status != md.StatusSuccess || len(manifest.Derivatives) != 1 {
+			t.Errorf("Unexpected final manifest: %#v", manifest)
+		}
+		if len(percents) != 3 || percents[1] != 45 || percents[2] != 100 {
+			t.Errorf("Unexpected progress: %v", percents)
+		}
+		if len(progress) != 3 {
+			t.Errorf("Expected 3 progress reports on channel, got %d", len(progress))
+		}
+	})
+
+	t.Run("Report failed translation", func(t *testing.T) {
+		server := newManifestServer(`{"status":"failed","progress":"complete","derivatives":[
+			{"status":"failed","outputType":"svf","messages":[{"type":"error","code":"TranslationWorker-InternalFailure"}]}]}`)
+		defer server.Close()
+
+		_, err := newTestMDAPI(server).WaitForTranslation(context.Background(), "urn", options)
+
+		var translationErr *md.TranslationError
+		if !errors.As(err, &translationErr) {
+			t.Fatalf("Expected a TranslationError, got %v", err)
+		}
+		if len(translationErr.Derivatives) != 1 || translationErr.Derivatives[0].Messages[0].Code != "TranslationWorker-InternalFailure" {
+			t.Errorf("Expected the failed derivative messages, got %#v", translationErr.Derivatives)
+		}
+	})
+
+	t.Run("Stop waiting when context is done", func(t *testing.T) {
+		server := newManifestServer(`{"status":"inprogress","progress":"10% complete"}`)
+		defer server.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		_, err := newTestMDAPI(server).WaitForTranslation(ctx, "urn", options)
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package md_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/md"
+	"testing"
+)
+
+func TestManifest_Traversal(t *testing.T) {
+
+	data := []byte(`{"status":"success","derivatives":[
+		{"outputType":"svf","children":[
+			{"guid":"3d-view","type":"geometry","role":"3d","children":[
+				{"guid":"svf","type":"resource","role":"graphics","mime":"application/autodesk-svf","urn":"urn:svf"},
+				{"guid":"thumb","type":"resource","role":"thumbnail","mime":"image/png","urn":"urn:thumb"}]},
+			{"guid":"sheet","type":"geometry","role":"2d","children":[
+				{"guid":"f2d","type":"resource","role":"graphics","mime":"application/autodesk-f2d","urn":"urn:f2d"}]},
+			{"guid":"db","type":"resource","role":"Autodesk.CloudPlatform.PropertyDatabase","mime":"application/autodesk-db","urn":"urn:db"}]},
+		{"outputType":"pdf","children":[
+			{"guid":"pdf","type":"resource","role":"2d","mime":"application/pdf","urn":"urn:pdf"}]}]}`)
+
+	var manifest md.Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatalf("Failed to decode manifest: %s\n", err.Error())
+	}
+
+	t.Run("Find viewables", func(t *testing.T) {
+		views3D := manifest.Viewables(md.Role3D)
+		views2D := manifest.Viewables(md.Role2D)
+		if len(views3D) != 1 || views3D[0].GUID != "3d-view" || len(views2D) != 1 || views2D[0].GUID != "sheet" {
+			t.Errorf("Wrong viewables: %v and %v", views3D, views2D)
+		}
+	})
+
+	t.Run("Find by role and mime", func(t *testing.T) {
+		thumbnails := manifest.FindByRole(md.RoleThumbnail)
+		if len(thumbnails) != 1 || thumbnails[0].URN != "urn:thumb" {
+			t.Errorf("Wrong thumbnails: %v", thumbnails)
+		}
+
+		pdfs := manifest.FindByMime("application/pdf")
+		if len(pdfs) != 1 || pdfs[0].URN != "urn:pdf" {
+			t.Errorf("Wrong pdf derivatives: %v", pdfs)
+		}
+	})
+
+	t.Run("List derivative URNs", func(t *testing.T) {
+		urns := manifest.DerivativeURNs()
+		expected := []string{"urn:svf", "urn:thumb", "urn:f2d", "urn:db", "urn:pdf"}
+		if len(urns) != len(expected) {
+			t.Fatalf("Expected %v, got %v", expected, urns)
+		}
+		for idx := range expected {
+			if urns[idx] != expected[idx] {
+				t.Errorf("Expected %v, got %v", expected, urns)
+				break
+			}
+		}
+	})
+
+	t.Run("Skip children and stop walking", func(t *testing.T) {
+		var visited []string
+		err := manifest.Walk(func(child *md.Child) error {
+			visited = append(visited, child.GUID)
+			if child.Role == md.Role3D {
+				return md.SkipChildren
+			}
+			if child.GUID == "db" {
+				return errors.New("stop")
+			}
+			return nil
+		})
+
+		if err == nil || err.Error() != "stop" || len(visited) != 4 {
+			t.Errorf("Expected to visit 4 children before stopping, got %v with error %v", visited, err)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package md
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// The sizes of thumbnails supported by the service, used both for width and height
+const (
+	ThumbnailSmall  = 100
+	ThumbnailMedium = 200
+	ThumbnailLarge  = 400
+)
+
+// sniffLength is the number of bytes considered when detecting the content type of a thumbnail
+const sniffLength = 512
+
+// Thumbnail holds the image of a thumbnail along with its content type, g.e. "image/png"
+type Thumbnail struct {
+	ContentType string
+	Data        []byte
+}
+
+// ValidateThumbnailSize checks that width and height are supported thumbnail sizes,
+// 0 meaning the size is not specified and the service chooses it.
+func ValidateThumbnailSize(width, height int) error {
+	for _, size := range []int{width, height} {
+		switch size {
+		case 0, ThumbnailSmall, ThumbnailMedium, ThumbnailLarge:
+		default:
+			return errors.New("unsupported thumbnail size " + strconv.Itoa(size) + ", expecting 100, 200 or 400")
+		}
+	}
+	return nil
+}
+
+// GetThumbnail downloads the thumbnail of a translated model, with given width and height in pixels,
+// each being one of ThumbnailSmall, ThumbnailMedium or ThumbnailLarge, or 0 to let the service choose.
+// 	The content type is taken from the response, or detected from the image when the service does not report it.
+func (a ModelDerivativeAPI) GetThumbnail(urn URN, width, height int) (result Thumbnail, err error) {
+	return a.GetThumbnailContext(context.Background(), urn, width, height)
+}
+
+// GetThumbnailContext is the same as GetThumbnail, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) GetThumbnailContext(ctx context.Context, urn URN, width, height int) (result Thumbnail, err error) {
+	reader, contentType, err := a.OpenThumbnailContext(ctx, urn, width, height)
+	if err != nil {
+		return
+	}
+	defer reader.Close()
+
+	result.ContentType = contentType
+	result.Data, err = ioutil.ReadAll(reader)
+
+	return
+}
+
+// OpenThumbnail is the same as GetThumbnail, but returns a reader on the image, which must be closed by the caller.
+func (a ModelDerivativeAPI) OpenThumbnail(urn URN, width, height int) (reader io.ReadCloser, contentType string, err error) {
+	return a.OpenThumbnailContext(context.Background(), urn, width, height)
+}
+
+// OpenThumbnailContext is the same as OpenThumbnail, but takes a context allowing to cancel the request or to set its deadline.
+func (a ModelDerivativeAPI) OpenThumbnailContext(ctx context.Context, urn URN, width, height int) (reader io.ReadCloser, contentType string, err error) {
+	if err = ValidateThumbnailSize(width, height); err != nil {
+		return
+	}
+
+	bearer, err := oauth.GetTokenContext(ctx, a.Authenticator, "data:read")
+	if err != nil {
+		return
+	}
+	path := a.Authenticator.GetHostPath() + a.ModelDerivativePath
+	return openThumbnail(ctx, oauth.ClientOrDefault(a.Client), path, urn.normalize(), width, height, bearer.AccessToken)
+}
+
+/*
+ *	SUPPORT FUNCTIONS
+ */
+
+func openThumbnail(ctx context.Context, client oauth.Doer, path, urn string, width, height int, token string) (reader io.ReadCloser, contentType string, err error) {
+	query := url.Values{}
+	if width != 0 {
+		query.Set("width", strconv.Itoa(width))
+	}
+	if height != 0 {
+		query.Set("height", strconv.Itoa(height))
+	}
+
+	thumbnailPath := path + "/" + urn + "/thumbnail"
+	if len(query) != 0 {
+		thumbnailPath += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequest("GET",
+		thumbnailPath,
+		nil,
+	)
+
+	if err != nil {
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		err = oauth.NewAPIError(response)
+		response.Body.Close()
+		return
+	}
+
+	contentType = response.Header.Get("Content-Type")
+	if mediaType, _, parseErr := mime.ParseMediaType(contentType); parseErr == nil && mediaType != "application/octet-stream" {
+		return response.Body, contentType, nil
+	}
+
+	// the service did not tell the image format, detect it from the first bytes without consuming them
+	buffered := bufio.NewReaderSize(response.Body, sniffLength)
+	head, peekErr := buffered.Peek(sniffLength)
+	if peekErr != nil && peekErr != io.EOF && peekErr != bufio.ErrBufferFull {
+		response.Body.Close()
+		err = peekErr
+		return
+	}
+	contentType = http.DetectContentType(head)
+
+	reader = struct {
+		io.Reader
+		io.Closer
+	}{buffered, response.Body}
+
+	return
+}
@@ -1,13 +1,12 @@
 package md
 
 import (
+	"context"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
 	"log"
 	"net/http"
 	"bytes"
-	"io/ioutil"
-	"errors"
 	"encoding/json"
-	"strconv"
 )
 
 //TranslationParams is used when specifying the translation jobs
@@ -23,7 +22,7 @@ type TranslationParams struct {
 // TranslationResult reflects data received upon successful creation of translation job
 type TranslationResult struct {
 	Result string `json:"result"`
-	URN    string `json:"urn"`
+	URN    URN    `json:"urn"`
 	AcceptedJobs struct {
 		Output OutputSpec `json:"output"`
 	}
@@ -41,13 +40,16 @@ type DestSpec struct {
 }
 
 // FormatSpec is used within OutputSpecs and should be used when specifying the expected format and views (2d or/and 3d)
+// 	Views apply only to svf and svf2, being omitted for the other formats.
+// 	Advanced holds the settings specific to the format, g.e. SVFAdvanced, see the builders implementing Format.
 type FormatSpec struct {
-	Type  string   `json:"type"`
-	Views []string `json:"views"`
+	Type     string      `json:"type"`
+	Views    []string    `json:"views,omitempty"`
+	Advanced interface{} `json:"advanced,omitempty"`
 }
 
 
-func translate(path string, params TranslationParams, token string) (result TranslationResult, err error) {
+func translate(ctx context.Context, client oauth.Doer, path string, params TranslationParams, token string) (result TranslationResult, err error) {
 
 	byteParams, err := json.Marshal(params)
 	if err != nil {
@@ -66,15 +68,14 @@ func translate(path string, params TranslationParams, token string) (result Tran
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", "Bearer "+token)
 
-	response, err := http.DefaultClient.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = oauth.NewAPIError(response)
 		return
 	}
 
@@ -0,0 +1,37 @@
+package md
+
+import (
+	"encoding/base64"
+	"strings"
+)
+
+// URN identifies a source file for the Model Derivative API: its object id, g.e.
+// "urn:adsk.objects:os.object:bucket/model.rvt", encoded in base64 with the URL-safe alphabet and without padding.
+// 	The APIs also accept URNs encoded with the standard alphabet or padded, converting them before building the request.
+type URN string
+
+// EncodeURN returns the URN of an object, given its id as returned when uploading it.
+func EncodeURN(objectID string) URN {
+	return URN(base64.RawURLEncoding.EncodeToString([]byte(objectID)))
+}
+
+// DecodeURN returns the object id encoded in the URN, accepting both URL-safe and standard alphabets, padded or not.
+func DecodeURN(urn URN) (objectID string, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(urn.normalize())
+	if err != nil {
+		return
+	}
+	return string(decoded), nil
+}
+
+// String returns the URN as given
+func (u URN) String() string {
+	return string(u)
+}
+
+// normalize converts the URN to the URL-safe alphabet without padding, expected in the request paths,
+// the characters '/' and '+' of the standard alphabet otherwise breaking the path.
+func (u URN) normalize() string {
+	normalized := strings.TrimRight(strings.TrimSpace(string(u)), "=")
+	return strings.NewReplacer("+", "-", "/", "_").Replace(normalized)
+}
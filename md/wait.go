@@ -0,0 +1,183 @@
+package md
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The statuses of a manifest and of its derivatives
+const (
+	StatusPending    = "pending"
+	StatusInProgress = "inprogress"
+	StatusSuccess    = "success"
+	StatusFailed     = "failed"
+	StatusTimeout    = "timeout"
+)
+
+const (
+	// DefaultPollInterval is the delay before first poll of the manifest, when none is specified in WaitOptions
+	DefaultPollInterval = 5 * time.Second
+	// DefaultMaxPollInterval is the upper limit of the delay between polls, when none is specified in WaitOptions
+	DefaultMaxPollInterval = time.Minute
+)
+
+// WaitOptions specifies how WaitForTranslation polls the manifest and reports the progress
+type WaitOptions struct {
+	Interval    time.Duration // The delay between first polls, growing up to MaxInterval
+	MaxInterval time.Duration // The upper limit of the delay between polls
+	// OnProgress, if set, is called with the progress after each poll
+	OnProgress func(progress TranslationProgress)
+	// Progress, if set, receives the progress after each poll. It is not closed when the waiting ends.
+	Progress chan<- TranslationProgress
+}
+
+// TranslationProgress reflects the state of a translation job, as reported by its manifest
+type TranslationProgress struct {
+	Status   string // One of StatusPending, StatusInProgress, StatusSuccess, StatusFailed or StatusTimeout
+	Percent  int    // The percentage of completion, parsed from manifest progress like "45% complete"
+	Manifest Manifest
+}
+
+// TranslationError is returned when a translation job failed or timed out,
+// carrying the failed derivatives along with their messages.
+type TranslationError struct {
+	URN         URN
+	Status      string
+	Progress    string
+	Derivatives []Derivative
+}
+
+// Error returns the status of the translation along with the derivative messages
+func (e *TranslationError) Error() string {
+	message := "translation of " + e.URN.String() + " ended with status " + e.Status
+	var reports []string
+	for _, msg := range e.Messages() {
+		report := msg.Type + " " + msg.Code
+		if text := msg.Text(); len(text) != 0 {
+			report += " (" + text + ")"
+		}
+		reports = append(reports, report)
+	}
+	if len(reports) != 0 {
+		message += ": " + strings.Join(reports, ", ")
+	}
+	return message
+}
+
+// Messages returns the messages reported on the failed derivatives and on their children
+func (e *TranslationError) Messages() (messages []Message) {
+	for _, derivative := range e.Derivatives {
+		messages = append(messages, derivative.Messages...)
+		for _, child := range derivative.Children {
+			messages = append(messages, childMessages(child)...)
+		}
+	}
+	return
+}
+
+func childMessages(child Child) (messages []Message) {
+	messages = append(messages, child.Messages...)
+	for _, grandChild := range child.Children {
+		messages = append(messages, childMessages(grandChild)...)
+	}
+	return
+}
+
+// WaitForTranslation polls the manifest of given URN until the translation job ends, increasing the delay between polls.
+// Returns the final manifest, or a *TranslationError if the job failed or timed out.
+// 	The waiting is stopped when the context is canceled or its deadline is exceeded.
+func (a ModelDerivativeAPI) WaitForTranslation(ctx context.Context, urn URN, options WaitOptions) (result Manifest, err error) {
+	interval := options.Interval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	maxInterval := options.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultMaxPollInterval
+	}
+	if maxInterval < interval {
+		maxInterval = interval
+	}
+
+	for {
+		result, err = a.GetManifestContext(ctx, urn)
+		if err != nil {
+			return
+		}
+
+		progress := TranslationProgress{
+			Status:   result.Status,
+			Percent:  parseProgress(result.Progress),
+			Manifest: result,
+		}
+		if options.OnProgress != nil {
+			options.OnProgress(progress)
+		}
+		if options.Progress != nil {
+			select {
+			case options.Progress <- progress:
+			case <-ctx.Done():
+				return result, ctx.Err()
+			}
+		}
+
+		switch result.Status {
+		case StatusSuccess:
+			return
+		case StatusFailed, StatusTimeout:
+			err = newTranslationError(urn, result)
+			return
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, ctx.Err()
+		case <-timer.C:
+		}
+
+		interval += interval / 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
+
+func newTranslationError(urn URN, manifest Manifest) *TranslationError {
+	translationErr := &TranslationError{
+		URN:      urn,
+		Status:   manifest.Status,
+		Progress: manifest.Progress,
+	}
+
+	for _, derivative := range manifest.Derivatives {
+		if derivative.Status == StatusFailed || derivative.Status == StatusTimeout || len(derivative.Messages) != 0 {
+			translationErr.Derivatives = append(translationErr.Derivatives, derivative)
+		}
+	}
+
+	return translationErr
+}
+
+// parseProgress extracts the percentage from progress like "45% complete", "complete" meaning 100
+func parseProgress(progress string) int {
+	progress = strings.TrimSpace(progress)
+	if progress == "complete" {
+		return 100
+	}
+
+	idx := strings.Index(progress, "%")
+	if idx <= 0 {
+		return 0
+	}
+
+	percent, err := strconv.Atoi(strings.TrimSpace(progress[:idx]))
+	if err != nil {
+		return 0
+	}
+
+	return percent
+}
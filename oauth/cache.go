@@ -0,0 +1,137 @@
+package oauth
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRefreshMargin specifies how long before its expiration a cached token is considered stale
+const DefaultRefreshMargin = 5 * time.Minute
+
+// minCacheDuration is how long a token is kept at least, when its lifetime is shorter than the refresh margin
+const minCacheDuration = 10 * time.Second
+
+// CachedAuthenticator wraps a ForgeAuthenticator and caches the received tokens per scope,
+// asking for a new token only when the cached one is about to expire.
+// 	It is safe for concurrent use and can be passed to any API expecting a ForgeAuthenticator.
+type CachedAuthenticator struct {
+	ForgeAuthenticator
+	RefreshMargin time.Duration
+
+	mutex  sync.Mutex
+	tokens map[string]*cachedToken
+}
+
+type cachedToken struct {
+	// lock is a semaphore allowing the waiting callers to give up when their context is done
+	lock      chan struct{}
+	bearer    Bearer
+	refreshAt time.Time
+	expiresAt time.Time
+}
+
+// NewCachedTwoLegged returns a 2-legged authenticator with default host and authPath, that caches its tokens
+func NewCachedTwoLegged(clientID, clientSecret string) *CachedAuthenticator {
+	return NewCachedAuthenticator(NewTwoLegged(clientID, clientSecret))
+}
+
+// NewCachedAuthenticator returns an authenticator caching the tokens received from the given one
+func NewCachedAuthenticator(authenticator ForgeAuthenticator) *CachedAuthenticator {
+	return &CachedAuthenticator{
+		ForgeAuthenticator: authenticator,
+		RefreshMargin:      DefaultRefreshMargin,
+		tokens:             make(map[string]*cachedToken),
+	}
+}
+
+// GetToken returns the cached token for the given scope, or a new one if missing or about to expire.
+// The ExpiresIn of returned token reflects the time left until its expiration.
+func (a *CachedAuthenticator) GetToken(scope string) (bearer Bearer, err error) {
+	return a.GetTokenContext(context.Background(), scope)
+}
+
+// GetTokenContext is the same as GetToken, but takes a context allowing to cancel the request or to set its deadline.
+func (a *CachedAuthenticator) GetTokenContext(ctx context.Context, scope string) (bearer Bearer, err error) {
+	entry := a.entry(scope)
+
+	// only one request per scope is made, the concurrent callers are waiting for its result
+	select {
+	case entry.lock <- struct{}{}:
+	case <-ctx.Done():
+		err = ctx.Err()
+		return
+	}
+	defer func() { <-entry.lock }()
+
+	now := time.Now()
+	if len(entry.bearer.AccessToken) != 0 && now.Before(entry.refreshAt) {
+		bearer = entry.bearer
+		bearer.ExpiresIn = int32(entry.expiresAt.Sub(now) / time.Second)
+		return
+	}
+
+	bearer, err = GetTokenContext(ctx, a.ForgeAuthenticator, scope)
+	if err != nil {
+		return
+	}
+
+	// a token living less than the refresh margin is still kept for a while, instead of being requested on each call
+	lifetime := time.Duration(bearer.ExpiresIn) * time.Second
+	cacheDuration := lifetime - a.RefreshMargin
+	if cacheDuration < minCacheDuration {
+		cacheDuration = minCacheDuration
+	}
+	if cacheDuration > lifetime {
+		cacheDuration = lifetime
+	}
+
+	entry.bearer = bearer
+	entry.refreshAt = now.Add(cacheDuration)
+	entry.expiresAt = now.Add(lifetime)
+
+	return
+}
+
+// Invalidate removes from cache the token for given scope, forcing the next GetToken to request a new one
+func (a *CachedAuthenticator) Invalidate(scope string) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	delete(a.tokens, normalizeScope(scope))
+}
+
+// Clear removes all the cached tokens
+func (a *CachedAuthenticator) Clear() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.tokens = make(map[string]*cachedToken)
+}
+
+func (a *CachedAuthenticator) entry(scope string) *cachedToken {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.tokens == nil {
+		a.tokens = make(map[string]*cachedToken)
+	}
+
+	key := normalizeScope(scope)
+	entry, ok := a.tokens[key]
+	if !ok {
+		entry = &cachedToken{lock: make(chan struct{}, 1)}
+		a.tokens[key] = entry
+	}
+
+	return entry
+}
+
+// normalizeScope makes "data:read data:write" and "data:write  data:read" share the same cached token
+func normalizeScope(scope string) string {
+	scopes := strings.Fields(scope)
+	sort.Strings(scopes)
+	return strings.Join(scopes, " ")
+}
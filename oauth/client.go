@@ -0,0 +1,17 @@
+package oauth
+
+import "net/http"
+
+// Doer defines the interface used to send HTTP requests, satisfied by *http.Client.
+// 	It allows to provide a client with custom timeouts, proxies, TLS settings or transport.
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ClientOrDefault returns the given client, or the DefaultClient if none was provided
+func ClientOrDefault(client Doer) Doer {
+	if client == nil {
+		return DefaultClient
+	}
+	return client
+}
@@ -0,0 +1,18 @@
+package oauth
+
+import "context"
+
+// GetTokenContext gets a token with the given scope from authenticator, passing along the context
+// if the authenticator is a ContextAuthenticator.
+// 	Other authenticators cannot be interrupted, so the context is only checked before asking for the token.
+func GetTokenContext(ctx context.Context, authenticator ForgeAuthenticator, scope string) (bearer Bearer, err error) {
+	if ctxAuthenticator, ok := authenticator.(ContextAuthenticator); ok {
+		return ctxAuthenticator.GetTokenContext(ctx, scope)
+	}
+
+	if err = ctx.Err(); err != nil {
+		return
+	}
+
+	return authenticator.GetToken(scope)
+}
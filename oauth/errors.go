@@ -0,0 +1,115 @@
+package oauth
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+)
+
+// APIError reflects a request rejected by a Forge service, carrying the details necessary to handle it
+// without parsing the error message. Use errors.As or helpers like IsNotFound to inspect it.
+type APIError struct {
+	StatusCode int    // The HTTP status code of the response
+	Method     string // The method of the failed request
+	Endpoint   string // The URL of the failed request, without the query
+	Body       []byte // The raw body of the response, if any
+	Code       string // The error code reported by the service, if any
+	Reason     string // The error message reported by the service, if any
+	// Details holds the service specific error, g.e. recap.Error or da.AppUploadError, when it could be decoded
+	Details interface{}
+	// Message, if set, is returned by Error instead of the default message, keeping the text of service specific errors
+	Message string
+}
+
+// Error returns the status code along with the received body, as "[404] {...}", unless Message is set
+func (e *APIError) Error() string {
+	if len(e.Message) != 0 {
+		return e.Message
+	}
+	return "[" + strconv.Itoa(e.StatusCode) + "] " + string(e.Body)
+}
+
+// NewAPIError reads the body of a failed response and builds an APIError out of it,
+// decoding the error fields used across Forge services.
+func NewAPIError(response *http.Response) *APIError {
+	apiErr := &APIError{StatusCode: response.StatusCode}
+
+	if request := response.Request; request != nil {
+		apiErr.Method = request.Method
+		if request.URL != nil {
+			endpoint := *request.URL
+			endpoint.RawQuery = ""
+			apiErr.Endpoint = endpoint.String()
+		}
+	}
+
+	apiErr.Body, _ = ioutil.ReadAll(response.Body)
+
+	// the services are not consistent in how they report errors, thus try the known variants
+	var content struct {
+		Reason           string `json:"reason"`
+		DeveloperMessage string `json:"developerMessage"`
+		Diagnostic       string `json:"diagnostic"`
+		Message          string `json:"message"`
+		Msg              string `json:"msg"`
+		ErrorCode        string `json:"errorCode"`
+		Code             string `json:"code"`
+	}
+	json.Unmarshal(apiErr.Body, &content)
+
+	apiErr.Code = firstNonEmpty(content.ErrorCode, content.Code)
+	apiErr.Reason = firstNonEmpty(content.Reason, content.DeveloperMessage, content.Diagnostic,
+		content.Message, content.Msg)
+
+	return apiErr
+}
+
+// StatusCode returns the HTTP status code carried by an APIError, or 0 if err is not an APIError
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsBadRequest reports if err is an APIError with 400 Bad Request status
+func IsBadRequest(err error) bool {
+	return StatusCode(err) == http.StatusBadRequest
+}
+
+// IsUnauthorized reports if err is an APIError with 401 Unauthorized status
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports if err is an APIError with 403 Forbidden status
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsNotFound reports if err is an APIError with 404 Not Found status
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsConflict reports if err is an APIError with 409 Conflict status, g.e. when creating an already existing bucket
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsTooManyRequests reports if err is an APIError with 429 Too Many Requests status, meaning the rate limit was hit
+func IsTooManyRequests(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if len(value) != 0 {
+			return value
+		}
+	}
+	return ""
+}
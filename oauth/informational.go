@@ -1,11 +1,9 @@
 package oauth
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
 	"net/http"
-	"strconv"
 )
 
 // UserProfile reflects the response received when query the profile of an authorizing end user in a 3-legged context
@@ -30,6 +28,7 @@ type UserProfile struct {
 type Information struct {
 	Authenticator        ForgeAuthenticator
 	InformationalAPIPath string
+	Client               Doer
 	//Host        string `json:"host,omitempty"`
 	//ProfilePath string `json:"profile_path"`
 }
@@ -48,14 +47,20 @@ func NewInformationQuerier(authenticator ForgeAuthenticator) Information {
 	return Information{
 		authenticator,
 		"/userprofile/v1/users/@me",
+		DefaultClient,
 	}
 }
 
 //AboutMe is used to get the profile of an authorizing end user
 func (i Information) AboutMe() (profile UserProfile, err error) {
+	return i.AboutMeContext(context.Background())
+}
+
+// AboutMeContext is the same as AboutMe, but takes a context allowing to cancel the request or to set its deadline.
+func (i Information) AboutMeContext(ctx context.Context) (profile UserProfile, err error) {
 
 	requestPath := i.Authenticator.GetHostPath() + i.InformationalAPIPath
-	task := http.Client{}
+	task := ClientOrDefault(i.Client)
 
 	req, err := http.NewRequest("GET",
 		requestPath,
@@ -66,13 +71,13 @@ func (i Information) AboutMe() (profile UserProfile, err error) {
 		return
 	}
 
-	bearer, err := i.Authenticator.GetToken("user-profile:read")
+	bearer, err := GetTokenContext(ctx, i.Authenticator, "user-profile:read")
 	if err != nil {
 		return
 	}
 
 	req.Header.Set("Authorization", "Bearer "+bearer.AccessToken)
-	response, err := task.Do(req)
+	response, err := task.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return
@@ -80,8 +85,7 @@ func (i Information) AboutMe() (profile UserProfile, err error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = NewAPIError(response)
 		return
 	}
 
@@ -0,0 +1,150 @@
+package oauth
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy specifies how throttled (429) and failed (5xx) requests are retried.
+// 	The delay between attempts grows exponentially from BaseDelay up to MaxDelay, with a random jitter,
+// 	unless the server specified it through the Retry-After header.
+type RetryPolicy struct {
+	MaxRetries int           // How many times a request is retried, 0 disables retrying
+	BaseDelay  time.Duration // The delay before first retry
+	MaxDelay   time.Duration // The upper limit for the delay between retries
+	// RetryNonIdempotent allows replaying POST and PATCH requests that failed with 5xx status.
+	// These are retried by default only when throttled, as the server did not process them.
+	RetryNonIdempotent bool
+}
+
+// DefaultRetryPolicy is used by the DefaultClient
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	BaseDelay:  500 * time.Millisecond,
+	MaxDelay:   30 * time.Second,
+}
+
+// DefaultClient is used when no client was provided, retrying requests according to DefaultRetryPolicy
+var DefaultClient Doer = NewRetryingClient(http.DefaultClient, DefaultRetryPolicy)
+
+// RetryingClient wraps a Doer, retrying the requests according to the given Policy
+type RetryingClient struct {
+	Client Doer
+	Policy RetryPolicy
+}
+
+// NewRetryingClient returns a client retrying the requests sent through given client according to the policy.
+// If client is nil, the http.DefaultClient is used.
+func NewRetryingClient(client Doer, policy RetryPolicy) *RetryingClient {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &RetryingClient{client, policy}
+}
+
+// Do sends the request, retrying it while it is throttled or failing with a server error.
+// The waiting between the attempts is interrupted if the request context is done.
+func (c *RetryingClient) Do(req *http.Request) (response *http.Response, err error) {
+	client := c.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	for attempt := 0; ; attempt++ {
+		response, err = client.Do(req)
+		if err != nil || attempt >= c.Policy.MaxRetries || !c.shouldRetry(req, response) {
+			return
+		}
+
+		// the body was consumed by previous attempt, a new copy is needed to replay the request
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return
+			}
+			req.Body = body
+		}
+
+		delay := c.Policy.delay(attempt, response)
+		io.Copy(ioutil.Discard, response.Body)
+		response.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *RetryingClient) shouldRetry(req *http.Request, response *http.Response) bool {
+	switch response.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return c.Policy.RetryNonIdempotent || isIdempotent(req.Method)
+	}
+	return false
+}
+
+// delay computes the time to wait before next attempt, preferring the one specified by the server
+func (p RetryPolicy) delay(attempt int, response *http.Response) time.Duration {
+	if wait, ok := retryAfter(response); ok {
+		if p.MaxDelay > 0 && wait > p.MaxDelay {
+			return p.MaxDelay
+		}
+		return wait
+	}
+
+	backoff := p.BaseDelay << uint(attempt)
+	if backoff <= 0 || (p.MaxDelay > 0 && backoff > p.MaxDelay) {
+		backoff = p.MaxDelay
+	}
+	if backoff <= 0 {
+		return 0
+	}
+
+	// full jitter, to avoid all throttled clients retrying at the same moment
+	return time.Duration(rand.Int63n(int64(backoff))) + 1
+}
+
+// retryAfter parses the Retry-After header, given either as seconds or as HTTP date
+func retryAfter(response *http.Response) (time.Duration, bool) {
+	value := response.Header.Get("Retry-After")
+	if len(value) == 0 {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace,
+		http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
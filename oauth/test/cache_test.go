@@ -0,0 +1,173 @@
+package oauth_test
+
+import (
+	"context"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingAuthenticator hands out a new token on each call and counts the calls
+type countingAuthenticator struct {
+	sync.Mutex
+	calls     int
+	expiresIn int32
+}
+
+func (a *countingAuthenticator) GetToken(scope string) (oauth.Bearer, error) {
+	a.Lock()
+	defer a.Unlock()
+	a.calls++
+	return oauth.Bearer{
+		TokenType:   "Bearer",
+		ExpiresIn:   a.expiresIn,
+		AccessToken: "token" + strconv.Itoa(a.calls),
+	}, nil
+}
+
+func (a *countingAuthenticator) GetHostPath() string {
+	return "https://developer.api.autodesk.com"
+}
+
+func (a *countingAuthenticator) GetRefreshToken() string {
+	return ""
+}
+
+// blockingAuthenticator signals when asked for a token, then waits to be released before handing it out
+type blockingAuthenticator struct {
+	countingAuthenticator
+	started chan struct{}
+	release chan struct{}
+}
+
+func (a *blockingAuthenticator) GetToken(scope string) (oauth.Bearer, error) {
+	close(a.started)
+	<-a.release
+	return oauth.Bearer{TokenType: "Bearer", ExpiresIn: 3599, AccessToken: "token"}, nil
+}
+
+func TestCachedAuthenticator(t *testing.T) {
+
+	t.Run("Reuse the token for the same scope", func(t *testing.T) {
+		counter := &countingAuthenticator{expiresIn: 3599}
+		authenticator := oauth.NewCachedAuthenticator(counter)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := authenticator.GetToken("data:read data:write"); err != nil {
+					t.Error(err.Error())
+				}
+			}()
+		}
+		wg.Wait()
+
+		bearer, _ := authenticator.GetToken("data:write data:read")
+
+		if counter.calls != 1 {
+			t.Fatalf("Expected a single token request, got %d", counter.calls)
+		}
+
+		if bearer.ExpiresIn <= 0 || bearer.ExpiresIn > 3599 {
+			t.Fatalf("Wrong remaining expiration time: %d", bearer.ExpiresIn)
+		}
+	})
+
+	t.Run("Separate tokens for different scopes", func(t *testing.T) {
+		counter := &countingAuthenticator{expiresIn: 3599}
+		authenticator := oauth.NewCachedAuthenticator(counter)
+
+		read, _ := authenticator.GetToken("data:read")
+		write, _ := authenticator.GetToken("data:write")
+
+		if read.AccessToken == write.AccessToken {
+			t.Fatal("Different scopes should not share the same token")
+		}
+	})
+
+	t.Run("Keep short-lived token for a while", func(t *testing.T) {
+		counter := &countingAuthenticator{expiresIn: 60}
+		authenticator := oauth.NewCachedAuthenticator(counter)
+
+		first, _ := authenticator.GetToken("data:read")
+		second, _ := authenticator.GetToken("data:read")
+
+		if first.AccessToken != second.AccessToken {
+			t.Fatal("Token living less than the refresh margin should still be cached for a while")
+		}
+	})
+
+	t.Run("Refresh expired token", func(t *testing.T) {
+		counter := &countingAuthenticator{expiresIn: 0}
+		authenticator := oauth.NewCachedAuthenticator(counter)
+
+		first, _ := authenticator.GetToken("data:read")
+		second, _ := authenticator.GetToken("data:read")
+
+		if first.AccessToken == second.AccessToken {
+			t.Fatal("Expired token should be renewed")
+		}
+	})
+
+	t.Run("Stop waiting when the context is done", func(t *testing.T) {
+		blocking := &blockingAuthenticator{release: make(chan struct{}), started: make(chan struct{})}
+		authenticator := oauth.NewCachedAuthenticator(blocking)
+
+		go authenticator.GetToken("data:read")
+		<-blocking.started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := authenticator.GetTokenContext(ctx, "data:read")
+		close(blocking.release)
+
+		if err != context.DeadlineExceeded {
+			t.Fatalf("Expected the deadline to be exceeded while waiting, got %v", err)
+		}
+	})
+
+	t.Run("Invalidate the cached token", func(t *testing.T) {
+		counter := &countingAuthenticator{expiresIn: 3599}
+		authenticator := oauth.NewCachedAuthenticator(counter)
+
+		first, _ := authenticator.GetToken("data:read")
+		authenticator.Invalidate("data:read")
+		second, _ := authenticator.GetToken("data:read")
+
+		if first.AccessToken == second.AccessToken {
+			t.Fatal("Invalidated token should not be reused")
+		}
+	})
+}
+
+func TestCachedTwoLeggedAuthentication(t *testing.T) {
+
+	clientID := os.Getenv("FORGE_CLIENT_ID")
+	clientSecret := os.Getenv("FORGE_CLIENT_SECRET")
+
+	if len(clientID) == 0 || len(clientSecret) == 0 {
+		t.Fatalf("Could not get from env the Forge secrets")
+	}
+
+	authenticator := oauth.NewCachedTwoLegged(clientID, clientSecret)
+
+	first, err := authenticator.GetToken("data:read")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	second, err := authenticator.GetToken("data:read")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	if first.AccessToken != second.AccessToken {
+		t.Errorf("Expected the cached token to be reused")
+	}
+}
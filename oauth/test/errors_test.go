@@ -0,0 +1,83 @@
+package oauth_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestNewAPIError(t *testing.T) {
+
+	newResponse := func(status int, body string) *http.Response {
+		requestURL, _ := url.Parse("https://developer.api.autodesk.com/oss/v2/buckets/b/objects/o?signature=secret")
+		return &http.Response{
+			StatusCode: status,
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+			Request:    &http.Request{Method: "PUT", URL: requestURL},
+		}
+	}
+
+	t.Run("Decode OSS error", func(t *testing.T) {
+		apiErr := oauth.NewAPIError(newResponse(http.StatusNotFound, `{"reason":"Object not found"}`))
+
+		if apiErr.Reason != "Object not found" {
+			t.Errorf("Wrong reason: %s", apiErr.Reason)
+		}
+
+		if apiErr.Endpoint != "https://developer.api.autodesk.com/oss/v2/buckets/b/objects/o" {
+			t.Errorf("The endpoint should not contain the query, got: %s", apiErr.Endpoint)
+		}
+
+		if apiErr.Method != "PUT" {
+			t.Errorf("Wrong method: %s", apiErr.Method)
+		}
+	})
+
+	t.Run("Decode authentication error", func(t *testing.T) {
+		apiErr := oauth.NewAPIError(newResponse(http.StatusUnauthorized,
+			`{"developerMessage":"The client_id specified does not have access to the api product","errorCode":"AUTH-001"}`))
+
+		if apiErr.Code != "AUTH-001" || len(apiErr.Reason) == 0 {
+			t.Errorf("Wrong decoding: %#v", apiErr)
+		}
+	})
+
+	t.Run("Keep raw body when not JSON", func(t *testing.T) {
+		apiErr := oauth.NewAPIError(newResponse(http.StatusBadGateway, `<html>Bad Gateway</html>`))
+
+		if apiErr.Error() != "[502] <html>Bad Gateway</html>" {
+			t.Errorf("Unexpected error message: %s", apiErr.Error())
+		}
+	})
+
+	t.Run("Keep previous message format", func(t *testing.T) {
+		body := `{"reason":"Bucket already exists"}`
+		apiErr := oauth.NewAPIError(newResponse(http.StatusConflict, body))
+
+		if apiErr.Error() != "[409] "+body {
+			t.Errorf("Unexpected error message: %s", apiErr.Error())
+		}
+
+		apiErr.Message = "[409][BucketExists] - Bucket already exists {}"
+		if apiErr.Error() != apiErr.Message {
+			t.Errorf("The message should override the default one, got: %s", apiErr.Error())
+		}
+	})
+
+	t.Run("Detect status with wrapped error", func(t *testing.T) {
+		err := fmt.Errorf("uploading failed: %w", oauth.NewAPIError(newResponse(http.StatusTooManyRequests, "")))
+
+		if !oauth.IsTooManyRequests(err) {
+			t.Errorf("Expected too many requests error, got %v", err)
+		}
+
+		if oauth.IsNotFound(err) || oauth.IsNotFound(errors.New("[404] not found")) {
+			t.Error("Only APIErrors with 404 status should be reported as not found")
+		}
+	})
+}
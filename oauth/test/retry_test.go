@@ -0,0 +1,137 @@
+package oauth_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryingClient(t *testing.T) {
+
+	policy := oauth.RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   10 * time.Millisecond,
+	}
+
+	// newServer replies with given status for the first failures requests, then with 200 and the received body
+	newServer := func(status int, failures int32, calls *int32) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			if atomic.AddInt32(calls, 1) <= failures {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(status)
+				return
+			}
+			w.Write(body)
+		}))
+	}
+
+	t.Run("Retry throttled requests replaying the body", func(t *testing.T) {
+		var calls int32
+		server := newServer(http.StatusTooManyRequests, 2, &calls)
+		defer server.Close()
+
+		client := oauth.NewRetryingClient(server.Client(), policy)
+		req, _ := http.NewRequest("POST", server.URL, bytes.NewBufferString("payload"))
+
+		response, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		defer response.Body.Close()
+		body, _ := ioutil.ReadAll(response.Body)
+
+		if response.StatusCode != http.StatusOK || string(body) != "payload" {
+			t.Errorf("Expected the request to succeed with the same body, got [%d] %s", response.StatusCode, body)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 attempts, got %d", calls)
+		}
+	})
+
+	t.Run("Do not replay POST failing with server error", func(t *testing.T) {
+		var calls int32
+		server := newServer(http.StatusServiceUnavailable, 2, &calls)
+		defer server.Close()
+
+		client := oauth.NewRetryingClient(server.Client(), policy)
+		req, _ := http.NewRequest("POST", server.URL, bytes.NewBufferString("payload"))
+
+		response, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusServiceUnavailable || calls != 1 {
+			t.Errorf("Expected a single failed attempt, got [%d] after %d attempts", response.StatusCode, calls)
+		}
+	})
+
+	t.Run("Retry GET failing with server error", func(t *testing.T) {
+		var calls int32
+		server := newServer(http.StatusBadGateway, 2, &calls)
+		defer server.Close()
+
+		client := oauth.NewRetryingClient(server.Client(), policy)
+		req, _ := http.NewRequest("GET", server.URL, nil)
+
+		response, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusOK || calls != 3 {
+			t.Errorf("Expected success after 3 attempts, got [%d] after %d attempts", response.StatusCode, calls)
+		}
+	})
+
+	t.Run("Give up after max retries", func(t *testing.T) {
+		var calls int32
+		server := newServer(http.StatusTooManyRequests, 10, &calls)
+		defer server.Close()
+
+		client := oauth.NewRetryingClient(server.Client(), policy)
+		req, _ := http.NewRequest("GET", server.URL, nil)
+
+		response, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		response.Body.Close()
+
+		if response.StatusCode != http.StatusTooManyRequests || calls != 4 {
+			t.Errorf("Expected to give up after 4 attempts, got [%d] after %d attempts", response.StatusCode, calls)
+		}
+	})
+
+	t.Run("Stop waiting when context is canceled", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := oauth.NewRetryingClient(server.Client(), oauth.RetryPolicy{MaxRetries: 3, MaxDelay: time.Minute})
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequest("GET", server.URL, nil)
+
+		start := time.Now()
+		_, err := client.Do(req.WithContext(ctx))
+
+		if err == nil || time.Since(start) > 5*time.Second {
+			t.Errorf("Expected to stop waiting for retry when context is done, got %v", err)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package oauth_test
 
 import (
+	"context"
 	"fmt"
 	"github.com/apprentice3d/forge-api-go-client/oauth"
 	"log"
@@ -73,6 +74,19 @@ func TestTwoLeggedAuthentication(t *testing.T) {
 			t.Errorf("expected to not receive a token, but received: %s", bearer.AccessToken)
 		}
 	})
+
+	t.Run("Canceled context", func(t *testing.T) {
+		authenticator := oauth.NewTwoLegged(clientID, clientSecret)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		bearer, err := authenticator.GetTokenContext(ctx, "data:read")
+
+		if err == nil {
+			t.Errorf("Expected to fail due to canceled context, but got %v\n", bearer)
+		}
+	})
 }
 
 func ExampleTwoLeggedAuth_Authenticate() {
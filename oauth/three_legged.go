@@ -2,12 +2,10 @@ package oauth
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
 )
 
 
@@ -19,6 +17,7 @@ func NewThreeLegged(clientID, clientSecret, redirectURI, refreshToken string) *T
 			clientID,
 			clientSecret,
 			"https://developer.api.autodesk.com",
+			DefaultClient,
 			"/authentication/v1",
 		},
 		redirectURI,
@@ -63,8 +62,13 @@ func (a *ThreeLeggedAuth) SetRefreshToken(refreshtoken string) {
 
 //ExchangeCode is used to exchange the authorization code for a token and an exchange token
 func (a *ThreeLeggedAuth) ExchangeCode(code string) (bearer Bearer, err error) {
+	return a.ExchangeCodeContext(context.Background(), code)
+}
+
+// ExchangeCodeContext is the same as ExchangeCode, but takes a context allowing to cancel the request or to set its deadline.
+func (a *ThreeLeggedAuth) ExchangeCodeContext(ctx context.Context, code string) (bearer Bearer, err error) {
 
-	task := http.Client{}
+	task := ClientOrDefault(a.Client)
 
 	body := url.Values{}
 	body.Add("client_id", a.ClientID)
@@ -82,7 +86,7 @@ func (a *ThreeLeggedAuth) ExchangeCode(code string) (bearer Bearer, err error) {
 		return
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	response, err := task.Do(req)
+	response, err := task.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return
@@ -91,8 +95,7 @@ func (a *ThreeLeggedAuth) ExchangeCode(code string) (bearer Bearer, err error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = NewAPIError(response)
 		return
 	}
 
@@ -105,15 +108,26 @@ func (a *ThreeLeggedAuth) ExchangeCode(code string) (bearer Bearer, err error) {
 }
 
 func (a *ThreeLeggedAuth) GetToken(scope string) (token Bearer, err error) {
-	token, err = a.GetNewRefreshToken(a.RefreshToken, scope)
+	return a.GetTokenContext(context.Background(), scope)
+}
+
+// GetTokenContext is the same as GetToken, but takes a context allowing to cancel the request or to set its deadline.
+func (a *ThreeLeggedAuth) GetTokenContext(ctx context.Context, scope string) (token Bearer, err error) {
+	token, err = a.GetNewRefreshTokenContext(ctx, a.RefreshToken, scope)
 	a.RefreshToken = token.RefreshToken
 	return
 }
 
 // GetNewRefreshToken is used to get a new access token by using the refresh token provided by ExchangeCode
 func (a ThreeLeggedAuth) GetNewRefreshToken(refreshToken string, scope string) (bearer Bearer, err error) {
+	return a.GetNewRefreshTokenContext(context.Background(), refreshToken, scope)
+}
+
+// GetNewRefreshTokenContext is the same as GetNewRefreshToken, but takes a context allowing to cancel the request
+// or to set its deadline.
+func (a ThreeLeggedAuth) GetNewRefreshTokenContext(ctx context.Context, refreshToken string, scope string) (bearer Bearer, err error) {
 
-	task := http.Client{}
+	task := ClientOrDefault(a.Client)
 
 	body := url.Values{}
 	body.Add("client_id", a.ClientID)
@@ -131,7 +145,7 @@ func (a ThreeLeggedAuth) GetNewRefreshToken(refreshToken string, scope string) (
 		return
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	response, err := task.Do(req)
+	response, err := task.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return
@@ -140,8 +154,7 @@ func (a ThreeLeggedAuth) GetNewRefreshToken(refreshToken string, scope string) (
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = NewAPIError(response)
 		return
 	}
 	decoder := json.NewDecoder(response.Body)
@@ -2,12 +2,10 @@ package oauth
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"errors"
-	"io/ioutil"
 	"net/http"
 	"net/url"
-	"strconv"
 )
 
 // NewTwoLegged returns a 2-legged authenticator with default host and authPath
@@ -17,6 +15,7 @@ func NewTwoLegged(clientID, clientSecret string) *TwoLeggedAuth {
 			clientID,
 			clientSecret,
 			"https://developer.api.autodesk.com",
+			DefaultClient,
 			"/authentication/v1",
 		},
 
@@ -25,8 +24,13 @@ func NewTwoLegged(clientID, clientSecret string) *TwoLeggedAuth {
 
 // GetToken allows getting a token with a given scope
 func (a TwoLeggedAuth) GetToken(scope string) (bearer Bearer, err error) {
+	return a.GetTokenContext(context.Background(), scope)
+}
+
+// GetTokenContext is the same as GetToken, but takes a context allowing to cancel the request or to set its deadline.
+func (a TwoLeggedAuth) GetTokenContext(ctx context.Context, scope string) (bearer Bearer, err error) {
 
-	task := http.Client{}
+	task := ClientOrDefault(a.Client)
 
 	body := url.Values{}
 	body.Add("client_id", a.ClientID)
@@ -43,7 +47,7 @@ func (a TwoLeggedAuth) GetToken(scope string) (bearer Bearer, err error) {
 		return
 	}
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	response, err := task.Do(req)
+	response, err := task.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return
@@ -51,8 +55,7 @@ func (a TwoLeggedAuth) GetToken(scope string) (bearer Bearer, err error) {
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = NewAPIError(response)
 		return
 	}
 
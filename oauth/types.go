@@ -1,5 +1,7 @@
 package oauth
 
+import "context"
+
 // ForgeAuthenticator defines an interface that allows abstraction of 2-legged and a 3-legged context.
 // 	This provides useful when an API accepts both 2-legged and 3-legged context tokens
 type ForgeAuthenticator interface {
@@ -9,11 +11,19 @@ type ForgeAuthenticator interface {
 	//SetHostPath(path string)
 }
 
+// ContextAuthenticator defines an interface for authenticators able to abort the token acquisition
+// when the given context is canceled or its deadline is exceeded.
+type ContextAuthenticator interface {
+	ForgeAuthenticator
+	GetTokenContext(ctx context.Context, scope string) (Bearer, error)
+}
+
 // AuthData reflects the data common to 2-legged and 3-legged api calls
 type AuthData struct {
 	ClientID     string `json:"client_id,omitempty"`
 	ClientSecret string `json:"client_secret,omitempty"`
 	Host         string `json:"host,omitempty"`
+	Client       Doer   `json:"-"`
 	authPath     string
 }
 
@@ -2,9 +2,10 @@ package recap
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
-	"io/ioutil"
+	"github.com/apprentice3d/forge-api-go-client/oauth"
 	"log"
 	"mime/multipart"
 	"net/http"
@@ -15,13 +16,40 @@ import (
 	"math/rand"
 )
 
-func createPhotoScene(path string, name string, formats []string, sceneType string, token string) (scene PhotoScene, err error) {
+// newAPIError builds an APIError for a failed response, completing it with the ReCap specific Error, if found in body
+func newAPIError(response *http.Response) *oauth.APIError {
+	apiErr := oauth.NewAPIError(response)
+
+	content := ErrorMessage{}
+	if json.Unmarshal(apiErr.Body, &content) == nil && content.Error != nil {
+		apiErr.Code = content.Error.Code
+		apiErr.Reason = content.Error.Message
+		apiErr.Details = *content.Error
+	}
+
+	return apiErr
+}
+
+// newBodyError builds an APIError for a response with status OK, but containing an error in its body.
+// Check the bug section of this documentation for more info.
+func newBodyError(response *http.Response, bodyError *Error) *oauth.APIError {
+	apiErr := oauth.NewAPIError(response)
+	// the body was already consumed when decoding the reply
+	apiErr.Body = nil
+	apiErr.Code = bodyError.Code
+	apiErr.Reason = bodyError.Message
+	apiErr.Details = *bodyError
+	apiErr.Message = "[" + bodyError.Code + "] " + bodyError.Message
+
+	return apiErr
+}
+
+func createPhotoScene(ctx context.Context, client oauth.Doer, path string, name string, formats []string, sceneType string, token string) (scene PhotoScene, err error) {
 
 	if sceneType != "object" && sceneType != "aerial" {
 		err = errors.New("the scene type is not supported. Expecting 'object' or 'aerial', got " + sceneType)
 		return
 	}
-	task := http.Client{}
 
 	body := url.Values{}
 	body.Add("scenename", name)
@@ -38,15 +66,14 @@ func createPhotoScene(path string, name string, formats []string, sceneType stri
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -62,7 +89,7 @@ func createPhotoScene(path string, name string, formats []string, sceneType stri
 
 	// This check is necessary, as there are cases when server returns status OK, but contains an error message
 	if bodyError := sceneCreationReply.Error; bodyError != nil {
-		err = errors.New("[" + bodyError.Code + "] " + bodyError.Message)
+		err = newBodyError(response, bodyError)
 		return
 	}
 
@@ -71,9 +98,7 @@ func createPhotoScene(path string, name string, formats []string, sceneType stri
 	return
 }
 
-func addFileToSceneUsingLink(path string, photoSceneID string, link string, token string) (result FileUploadingReply, err error) {
-
-	task := http.Client{}
+func addFileToSceneUsingLink(ctx context.Context, client oauth.Doer, path string, photoSceneID string, link string, token string) (result FileUploadingReply, err error) {
 
 	//params := `photosceneid=` + photoSceneID + `&type=image`
 	//params += `&file[0]=` + link
@@ -97,7 +122,7 @@ func addFileToSceneUsingLink(path string, photoSceneID string, link string, toke
 
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		log.Println("could not send image links: ", err.Error())
 		return
@@ -105,8 +130,7 @@ func addFileToSceneUsingLink(path string, photoSceneID string, link string, toke
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -120,14 +144,14 @@ func addFileToSceneUsingLink(path string, photoSceneID string, link string, toke
 
 	// This check is necessary, as there are cases when server returns status OK, but contains an error message
 	if bodyError := result.Error; bodyError != nil {
-		err = errors.New("[" + bodyError.Code + "] " + bodyError.Message)
+		err = newBodyError(response, bodyError)
 		return
 	}
 
 	return
 }
 
-func addFileToSceneUsingFileData(path string, photoSceneID string, data []byte, token string) (result FileUploadingReply, err error) {
+func addFileToSceneUsingFileData(ctx context.Context, client oauth.Doer, path string, photoSceneID string, data []byte, token string) (result FileUploadingReply, err error) {
 
 	rand.Seed(time.Now().UnixNano())
 
@@ -143,8 +167,6 @@ func addFileToSceneUsingFileData(path string, photoSceneID string, data []byte,
 	formFile.Write(data)
 	writer.Close()
 
-	task := http.Client{}
-
 	req, err := http.NewRequest("POST",
 		path+"/file",
 		body)
@@ -155,7 +177,7 @@ func addFileToSceneUsingFileData(path string, photoSceneID string, data []byte,
 
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 
 	if err != nil {
 		return
@@ -163,8 +185,7 @@ func addFileToSceneUsingFileData(path string, photoSceneID string, data []byte,
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -178,16 +199,14 @@ func addFileToSceneUsingFileData(path string, photoSceneID string, data []byte,
 
 	// This check is necessary, as there are cases when server returns status OK, but contains an error message
 	if bodyError := result.Error; bodyError != nil {
-		err = errors.New("[" + bodyError.Code + "] " + bodyError.Message)
+		err = newBodyError(response, bodyError)
 		return
 	}
 
 	return
 }
 
-func startSceneProcessing(path string, photoSceneID string, token string) (result SceneStartProcessingReply, err error) {
-	task := http.Client{}
-
+func startSceneProcessing(ctx context.Context, client oauth.Doer, path string, photoSceneID string, token string) (result SceneStartProcessingReply, err error) {
 	req, err := http.NewRequest("POST",
 		path+"/photoscene/"+photoSceneID,
 		nil,
@@ -198,15 +217,14 @@ func startSceneProcessing(path string, photoSceneID string, token string) (resul
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -220,16 +238,14 @@ func startSceneProcessing(path string, photoSceneID string, token string) (resul
 
 	// This check is necessary, as there are cases when server returns status OK, but contains an error message
 	if bodyError := result.Error; bodyError != nil {
-		err = errors.New("[" + bodyError.Code + "] " + bodyError.Message)
+		err = newBodyError(response, bodyError)
 		return
 	}
 
 	return
 }
 
-func getSceneProgress(path string, photoSceneID string, token string) (result SceneProgressReply, err error) {
-	task := http.Client{}
-
+func getSceneProgress(ctx context.Context, client oauth.Doer, path string, photoSceneID string, token string) (result SceneProgressReply, err error) {
 	req, err := http.NewRequest("GET",
 		path+"/photoscene/"+photoSceneID+"/progress",
 		nil,
@@ -240,15 +256,14 @@ func getSceneProgress(path string, photoSceneID string, token string) (result Sc
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -262,16 +277,14 @@ func getSceneProgress(path string, photoSceneID string, token string) (result Sc
 
 	// This check is necessary, as there are cases when server returns status OK, but contains an error message
 	if bodyError := result.Error; bodyError != nil {
-		err = errors.New("[" + bodyError.Code + "] " + bodyError.Message)
+		err = newBodyError(response, bodyError)
 		return
 	}
 
 	return
 }
 
-func getSceneResult(path string, photoSceneID string, token string, format string) (result SceneResultReply, err error) {
-	task := http.Client{}
-
+func getSceneResult(ctx context.Context, client oauth.Doer, path string, photoSceneID string, token string, format string) (result SceneResultReply, err error) {
 	body := strings.NewReader("format=" + format)
 
 	req, err := http.NewRequest("GET",
@@ -284,15 +297,14 @@ func getSceneResult(path string, photoSceneID string, token string, format strin
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -306,16 +318,14 @@ func getSceneResult(path string, photoSceneID string, token string, format strin
 
 	// This check is necessary, as there are cases when server returns status OK, but contains an error message
 	if bodyError := result.Error; bodyError != nil {
-		err = errors.New("[" + bodyError.Code + "] " + bodyError.Message)
+		err = newBodyError(response, bodyError)
 		return
 	}
 
 	return
 }
 
-func cancelSceneProcessing(path string, photoSceneID string, token string) (result SceneCancelReply, err error) {
-	task := http.Client{}
-
+func cancelSceneProcessing(ctx context.Context, client oauth.Doer, path string, photoSceneID string, token string) (result SceneCancelReply, err error) {
 	req, err := http.NewRequest("POST",
 		path+"/photoscene/"+photoSceneID+"/cancel",
 		nil,
@@ -326,15 +336,14 @@ func cancelSceneProcessing(path string, photoSceneID string, token string) (resu
 	}
 
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -349,7 +358,7 @@ func cancelSceneProcessing(path string, photoSceneID string, token string) (resu
 
 	// This check is necessary, as there are cases when server returns status OK, but contains an error message
 	if bodyError := result.Error; bodyError != nil {
-		err = errors.New("[" + bodyError.Code + "] " + bodyError.Message)
+		err = newBodyError(response, bodyError)
 		return
 	}
 
@@ -357,9 +366,7 @@ func cancelSceneProcessing(path string, photoSceneID string, token string) (resu
 
 }
 
-func deleteScene(path string, photoSceneID string, token string) (result SceneDeletionReply, err error) {
-	task := http.Client{}
-
+func deleteScene(ctx context.Context, client oauth.Doer, path string, photoSceneID string, token string) (result SceneDeletionReply, err error) {
 	req, err := http.NewRequest("DELETE",
 		path+"/photoscene/"+photoSceneID,
 		nil,
@@ -370,15 +377,14 @@ func deleteScene(path string, photoSceneID string, token string) (result SceneDe
 	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Authorization", "Bearer "+token)
-	response, err := task.Do(req)
+	response, err := client.Do(req.WithContext(ctx))
 	if err != nil {
 		return
 	}
 	defer response.Body.Close()
 
 	if response.StatusCode != http.StatusOK {
-		content, _ := ioutil.ReadAll(response.Body)
-		err = errors.New("[" + strconv.Itoa(response.StatusCode) + "] " + string(content))
+		err = newAPIError(response)
 		return
 	}
 
@@ -392,7 +398,7 @@ func deleteScene(path string, photoSceneID string, token string) (result SceneDe
 
 	// This check is necessary, as there are cases when server returns status OK, but contains an error message
 	if bodyError := result.Error; bodyError != nil {
-		err = errors.New("[" + bodyError.Code + "] " + bodyError.Message)
+		err = newBodyError(response, bodyError)
 		return
 	}
 
@@ -9,6 +9,7 @@
 package recap
 
 import (
+	"context"
 	"github.com/apprentice3d/forge-api-go-client/oauth"
 )
 
@@ -16,6 +17,7 @@ import (
 type ReCapAPI struct {
 	Authenticator oauth.ForgeAuthenticator
 	ReCapPath string
+	Client oauth.Doer
 }
 
 // NewAPI returns a ReCap API client with default configurations
@@ -23,6 +25,7 @@ func NewAPI(authenticator oauth.ForgeAuthenticator) ReCapAPI {
 	return ReCapAPI{
 		authenticator,
 		"/photo-to-3d/v1",
+		oauth.DefaultClient,
 	}
 }
 
@@ -31,13 +34,18 @@ func NewAPI(authenticator oauth.ForgeAuthenticator) ReCapAPI {
 // 	formats - should be of type rcm, rcs, obj, ortho or report
 // 	sceneType - should be either "aerial" or "object"
 func (api ReCapAPI) CreatePhotoScene(name string, formats []string, sceneType string) (scene PhotoScene, err error) {
+	return api.CreatePhotoSceneContext(context.Background(), name, formats, sceneType)
+}
+
+// CreatePhotoSceneContext is the same as CreatePhotoScene, but takes a context allowing to cancel the request or to set its deadline.
+func (api ReCapAPI) CreatePhotoSceneContext(ctx context.Context, name string, formats []string, sceneType string) (scene PhotoScene, err error) {
 
-	bearer, err := api.Authenticator.GetToken("data:write")
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.ReCapPath
-	scene, err = createPhotoScene(path, name, formats, sceneType, bearer.AccessToken)
+	scene, err = createPhotoScene(ctx, oauth.ClientOrDefault(api.Client), path, name, formats, sceneType, bearer.AccessToken)
 
 	return
 }
@@ -45,52 +53,72 @@ func (api ReCapAPI) CreatePhotoScene(name string, formats []string, sceneType st
 // AddFileToSceneUsingLink can be used when the needed images are already available remotely
 // and can be uploaded just by providing the remote link
 func (api ReCapAPI) AddFileToSceneUsingLink(sceneID string, link string) (uploads FileUploadingReply, err error) {
+	return api.AddFileToSceneUsingLinkContext(context.Background(), sceneID, link)
+}
+
+// AddFileToSceneUsingLinkContext is the same as AddFileToSceneUsingLink, but takes a context allowing to cancel the request or to set its deadline.
+func (api ReCapAPI) AddFileToSceneUsingLinkContext(ctx context.Context, sceneID string, link string) (uploads FileUploadingReply, err error) {
 
-	bearer, err := api.Authenticator.GetToken("data:write")
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.ReCapPath
 
-	uploads, err = addFileToSceneUsingLink(path, sceneID, link, bearer.AccessToken)
+	uploads, err = addFileToSceneUsingLink(ctx, oauth.ClientOrDefault(api.Client), path, sceneID, link, bearer.AccessToken)
 	return
 }
 
 // AddFileToSceneUsingData can be used when the image is already available as a byte slice,
 // be it read from a local file or as a result/body of a POST request
 func (api ReCapAPI) AddFileToSceneUsingData(sceneID string, data []byte) (uploads FileUploadingReply, err error) {
+	return api.AddFileToSceneUsingDataContext(context.Background(), sceneID, data)
+}
+
+// AddFileToSceneUsingDataContext is the same as AddFileToSceneUsingData, but takes a context allowing to cancel the request or to set its deadline.
+func (api ReCapAPI) AddFileToSceneUsingDataContext(ctx context.Context, sceneID string, data []byte) (uploads FileUploadingReply, err error) {
 
-	bearer, err := api.Authenticator.GetToken("data:write")
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.ReCapPath
 
-	uploads, err = addFileToSceneUsingFileData(path, sceneID, data, bearer.AccessToken)
+	uploads, err = addFileToSceneUsingFileData(ctx, oauth.ClientOrDefault(api.Client), path, sceneID, data, bearer.AccessToken)
 
 	return
 }
 
 // StartSceneProcessing will trigger the processing of a specified scene that can be canceled any time
 func (api ReCapAPI) StartSceneProcessing(sceneID string) (result SceneStartProcessingReply, err error) {
-	bearer, err := api.Authenticator.GetToken("data:write")
+	return api.StartSceneProcessingContext(context.Background(), sceneID)
+}
+
+// StartSceneProcessingContext is the same as StartSceneProcessing, but takes a context allowing to cancel the request or to set its deadline.
+func (api ReCapAPI) StartSceneProcessingContext(ctx context.Context, sceneID string) (result SceneStartProcessingReply, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.ReCapPath
-	result, err = startSceneProcessing(path, sceneID, bearer.AccessToken)
+	result, err = startSceneProcessing(ctx, oauth.ClientOrDefault(api.Client), path, sceneID, bearer.AccessToken)
 	return
 }
 
 // GetSceneProgress polls the scene processing status and progress
 //	Note: instead of polling, consider using the callback parameter that can be specified upon scene creation
 func (api ReCapAPI) GetSceneProgress(sceneID string) (progress SceneProgressReply, err error) {
-	bearer, err := api.Authenticator.GetToken("data:read")
+	return api.GetSceneProgressContext(context.Background(), sceneID)
+}
+
+// GetSceneProgressContext is the same as GetSceneProgress, but takes a context allowing to cancel the request or to set its deadline.
+func (api ReCapAPI) GetSceneProgressContext(ctx context.Context, sceneID string) (progress SceneProgressReply, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.ReCapPath
-	progress, err = getSceneProgress(path, sceneID, bearer.AccessToken)
+	progress, err = getSceneProgress(ctx, oauth.ClientOrDefault(api.Client), path, sceneID, bearer.AccessToken)
 	return
 }
 
@@ -98,35 +126,50 @@ func (api ReCapAPI) GetSceneProgress(sceneID string) (progress SceneProgressRepl
 //	Note: The link specified in SceneResultReplies will be available for the time specified in reply,
 //	even if the scene is deleted
 func (api ReCapAPI) GetSceneResults(sceneID string, format string) (result SceneResultReply, err error) {
-	bearer, err := api.Authenticator.GetToken("data:read")
+	return api.GetSceneResultsContext(context.Background(), sceneID, format)
+}
+
+// GetSceneResultsContext is the same as GetSceneResults, but takes a context allowing to cancel the request or to set its deadline.
+func (api ReCapAPI) GetSceneResultsContext(ctx context.Context, sceneID string, format string) (result SceneResultReply, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:read")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.ReCapPath
-	result, err = getSceneResult(path, sceneID, bearer.AccessToken, format)
+	result, err = getSceneResult(ctx, oauth.ClientOrDefault(api.Client), path, sceneID, bearer.AccessToken, format)
 	return
 }
 
 // CancelSceneProcessing stops the scene processing, without affecting the already uploaded resources
 func (api ReCapAPI) CancelSceneProcessing(sceneID string) (ID string, err error) {
-	bearer, err := api.Authenticator.GetToken("data:write")
+	return api.CancelSceneProcessingContext(context.Background(), sceneID)
+}
+
+// CancelSceneProcessingContext is the same as CancelSceneProcessing, but takes a context allowing to cancel the request or to set its deadline.
+func (api ReCapAPI) CancelSceneProcessingContext(ctx context.Context, sceneID string) (ID string, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.ReCapPath
-	_, err = cancelSceneProcessing(path, sceneID, bearer.AccessToken)
+	_, err = cancelSceneProcessing(ctx, oauth.ClientOrDefault(api.Client), path, sceneID, bearer.AccessToken)
 
 	return sceneID, err
 }
 
 // DeleteScene removes all the resources associated with given scene.
 func (api ReCapAPI) DeleteScene(sceneID string) (ID string, err error) {
-	bearer, err := api.Authenticator.GetToken("data:write")
+	return api.DeleteSceneContext(context.Background(), sceneID)
+}
+
+// DeleteSceneContext is the same as DeleteScene, but takes a context allowing to cancel the request or to set its deadline.
+func (api ReCapAPI) DeleteSceneContext(ctx context.Context, sceneID string) (ID string, err error) {
+	bearer, err := oauth.GetTokenContext(ctx, api.Authenticator, "data:write")
 	if err != nil {
 		return
 	}
 	path := api.Authenticator.GetHostPath() + api.ReCapPath
-	_, err = deleteScene(path, sceneID, bearer.AccessToken)
+	_, err = deleteScene(ctx, oauth.ClientOrDefault(api.Client), path, sceneID, bearer.AccessToken)
 	ID = sceneID
 	return
 }